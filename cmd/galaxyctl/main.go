@@ -0,0 +1,217 @@
+// galaxyctl manages a running galaxy server through its /admin API.
+//
+// The server is read from GALAXY_ADMIN_URL (http://localhost:4440 by
+// default) and the token from GALAXY_ADMIN_TOKEN.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"galaxy.io/server/galaxy"
+)
+
+const usage = `usage: galaxyctl [-json] <command>
+
+commands:
+  players list          list every player and bot in the world
+  kick <playerID>       kick a player
+  mute <playerID>       stop a player from chatting
+  unmute <playerID>     let a muted player chat again
+  bots add <N>          spawn N bots
+  announce "<message>"  broadcast a message to every player
+  world stats           show a summary of the world
+  private save <gameID> upload the state of a private game
+  outbox list           show the queued backend calls and the dead letters
+  outbox retry <id>     queue a dead letter again
+  outbox discard <id>   drop a dead letter
+`
+
+type client struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+func main() {
+	log.SetFlags(0)
+
+	jsonOutput := flag.Bool("json", false, "print the response as JSON instead of a table")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	url := os.Getenv("GALAXY_ADMIN_URL")
+	if url == "" {
+		url = "http://localhost:4440"
+	}
+
+	token := os.Getenv("GALAXY_ADMIN_TOKEN")
+	if token == "" {
+		log.Fatalf("GALAXY_ADMIN_TOKEN is not set")
+	}
+
+	c := &client{
+		url:   url,
+		token: token,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var result any
+	var err error
+
+	switch {
+	case len(args) == 2 && args[0] == "players" && args[1] == "list":
+		var players []galaxy.PlayerInfo
+		err = c.do("GET", "/admin/players", nil, &players)
+		result = players
+	case len(args) == 2 && args[0] == "kick":
+		err = c.do("POST", "/admin/players/"+args[1]+"/kick", nil, nil)
+	case len(args) == 2 && args[0] == "mute":
+		err = c.do("POST", "/admin/players/"+args[1]+"/mute", nil, nil)
+	case len(args) == 2 && args[0] == "unmute":
+		err = c.do("DELETE", "/admin/mutes/"+args[1], nil, nil)
+	case len(args) == 3 && args[0] == "bots" && args[1] == "add":
+		count, convErr := strconv.Atoi(args[2])
+		if convErr != nil {
+			log.Fatalf("invalid number of bots %q", args[2])
+		}
+		var bots []galaxy.PlayerInfo
+		err = c.do("POST", "/admin/bots", galaxy.CountRequest{Count: count}, &bots)
+		result = bots
+	case len(args) == 2 && args[0] == "announce":
+		err = c.do("POST", "/admin/announce", galaxy.AnnounceRequest{Message: args[1]}, nil)
+	case len(args) == 2 && args[0] == "world" && args[1] == "stats":
+		var stats galaxy.WorldStats
+		err = c.do("GET", "/admin/world", nil, &stats)
+		result = stats
+	case len(args) == 3 && args[0] == "private" && args[1] == "save":
+		err = c.do("POST", "/admin/private/"+args[2]+"/save", nil, nil)
+	case len(args) == 2 && args[0] == "outbox" && args[1] == "list":
+		var status galaxy.OutboxStatus
+		err = c.do("GET", "/admin/outbox", nil, &status)
+		result = status
+	case len(args) == 3 && args[0] == "outbox" && args[1] == "retry":
+		err = c.do("POST", "/admin/outbox/"+args[2]+"/retry", nil, nil)
+	case len(args) == 3 && args[0] == "outbox" && args[1] == "discard":
+		err = c.do("DELETE", "/admin/outbox/"+args[2], nil, nil)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	if result == nil {
+		return
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+		return
+	}
+
+	printTable(result)
+}
+
+// do sends a request to the admin API, encoding body and decoding the
+// response into out when they are not nil.
+func (c *client) do(method string, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var errorResponse galaxy.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errorResponse) == nil && errorResponse.Error != "" {
+			return fmt.Errorf("%v (%v)", errorResponse.Error, resp.Status)
+		}
+		return fmt.Errorf("unexpected response %v", resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func printTable(result any) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	switch result := result.(type) {
+	case []galaxy.PlayerInfo:
+		fmt.Fprintln(w, "PLAYER ID\tUSERNAME\tBOT\tX\tY\tRADIUS\tADDRESS\tCONNECTED")
+		for _, player := range result {
+			connected := ""
+			if player.ConnectedAt != nil {
+				connected = time.Since(*player.ConnectedAt).Round(time.Second).String()
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+				player.PlayerID, player.Username, player.Bot,
+				player.X, player.Y, player.Radius,
+				player.RemoteAddr, connected)
+		}
+	case galaxy.WorldStats:
+		gameID := "-"
+		if result.GameID != nil {
+			gameID = strconv.FormatUint(uint64(*result.GameID), 10)
+		}
+		fmt.Fprintf(w, "humans\t%v\n", result.Humans)
+		fmt.Fprintf(w, "bots\t%v\n", result.Bots)
+		fmt.Fprintf(w, "spectators\t%v\n", result.Spectators)
+		fmt.Fprintf(w, "food\t%v\n", result.Food)
+		fmt.Fprintf(w, "private\t%v\n", result.PrivateServer)
+		fmt.Fprintf(w, "game id\t%v\n", gameID)
+		fmt.Fprintf(w, "seed\t%v\n", result.Seed)
+		fmt.Fprintf(w, "banned\t%v\n", result.Banned)
+	case galaxy.OutboxStatus:
+		fmt.Fprintf(w, "pending\t%v\n\n", result.Pending)
+		fmt.Fprintln(w, "DEAD LETTER\tCALL\tGAME ID\tATTEMPTS\tCREATED\tLAST ERROR")
+		for _, entry := range result.Dead {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n",
+				entry.ID, entry.Call, entry.GameID, entry.Attempts,
+				entry.CreatedAt.Format(time.DateTime), entry.LastError)
+		}
+	}
+}
@@ -0,0 +1,601 @@
+// Package config loads the settings of the server.
+//
+// Settings start from their defaults and are overridden, in order, by a
+// YAML file, by environment variables and by command line flags. Every
+// setting has a flag named after its position in the file, so world.width
+// in the file is -world.width on the command line.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	World       WorldConfig       `yaml:"world"`
+	Bots        BotConfig         `yaml:"bots"`
+	Leaderboard LeaderboardConfig `yaml:"leaderboard"`
+	Chat        ChatConfig        `yaml:"chat"`
+	Feed        FeedConfig        `yaml:"feed"`
+	Director    DirectorConfig    `yaml:"director"`
+	Spectators  SpectatorConfig   `yaml:"spectators"`
+	Backend     BackendConfig     `yaml:"backend"`
+	Websocket   WebsocketConfig   `yaml:"websocket"`
+	Record      RecordConfig      `yaml:"record"`
+	Log         LogConfig         `yaml:"log"`
+}
+
+type ServerConfig struct {
+	IP   string `yaml:"ip"`
+	Port string `yaml:"port"`
+	// Private servers host a single saved game at a time.
+	Private bool `yaml:"private"`
+	// Token required by the admin API, which is disabled when empty.
+	AdminToken string `yaml:"adminToken"`
+	// Time given to save games and close connections on shutdown.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+type WorldConfig struct {
+	Width  uint32 `yaml:"width"`
+	Height uint32 `yaml:"height"`
+	// Amount of food kept in the world.
+	Food           int    `yaml:"food"`
+	MaxPlayers     int    `yaml:"maxPlayers"`
+	StartingRadius uint32 `yaml:"startingRadius"`
+	// Bots are added while there are fewer players than this.
+	MinPlayers int `yaml:"minPlayers"`
+	// Seed of the world, a random one is picked when zero.
+	Seed uint64 `yaml:"seed"`
+	// Time new and respawned players can't eat or be eaten, zero disables
+	// it. It ends early once they eat or move SpawnProtectionDistance.
+	SpawnProtection         time.Duration `yaml:"spawnProtection"`
+	SpawnProtectionDistance uint32        `yaml:"spawnProtectionDistance"`
+}
+
+type BotConfig struct {
+	// Distance moved every step.
+	Speed uint32 `yaml:"speed"`
+	// Targets further away than this are ignored.
+	MaxRange uint32 `yaml:"maxRange"`
+	// How much closer food has to be for a bot to prefer it over a player.
+	PlayerPreference int32 `yaml:"playerPreference"`
+}
+
+type LeaderboardConfig struct {
+	// Time between leaderboards sent to the clients.
+	Interval time.Duration `yaml:"interval"`
+	// Players listed in every leaderboard.
+	Size int `yaml:"size"`
+}
+
+type ChatConfig struct {
+	// Chat is only available in the kinds of world enabled here.
+	Public  bool `yaml:"public"`
+	Private bool `yaml:"private"`
+	// Longest message accepted, in characters.
+	MaxLength int `yaml:"maxLength"`
+	// Players can send at most RateLimit messages every RatePeriod.
+	RateLimit  int           `yaml:"rateLimit"`
+	RatePeriod time.Duration `yaml:"ratePeriod"`
+	// Words replaced by asterisks in every message.
+	Filter []string `yaml:"filter"`
+}
+
+// FeedConfig holds the templates of the messages in the feed of notable
+// moments. {player} is replaced by who the moment is about, {victim} by
+// the player eaten and {radius} by the radius reached. An empty template
+// leaves those moments out of the feed.
+type FeedConfig struct {
+	Kill      string `yaml:"kill"`
+	BotEaten  string `yaml:"botEaten"`
+	NewLeader string `yaml:"newLeader"`
+	Milestone string `yaml:"milestone"`
+	// Radii announced when a player reaches them.
+	Milestones []uint32 `yaml:"milestones"`
+}
+
+// DirectorConfig tunes the camera the server moves for spectators.
+type DirectorConfig struct {
+	// Time between looks for something interesting.
+	Interval time.Duration `yaml:"interval"`
+	// The camera stays on a player at least this long, unless it leaves.
+	MinHold time.Duration `yaml:"minHold"`
+	// How many times more interesting a shot has to be to switch to it.
+	SwitchMargin float64 `yaml:"switchMargin"`
+}
+
+// SpectatorConfig holds back what spectators see, so they can't tell
+// players where the others are.
+type SpectatorConfig struct {
+	// The delay only applies in the kinds of world enabled here.
+	Public  bool `yaml:"public"`
+	Private bool `yaml:"private"`
+	// How long every event is held back before reaching a spectator,
+	// zero sends them right away.
+	Delay time.Duration `yaml:"delay"`
+	// Connections that only watch allowed at once, as each one holds back
+	// the events of the delay.
+	Max int `yaml:"max"`
+}
+
+// Kinds of backend.
+const (
+	// The production API.
+	BACKEND_HTTP = "http"
+	// Kept in memory and lost on restart.
+	BACKEND_MEMORY = "memory"
+	// Kept in a local JSON file.
+	BACKEND_FILE = "file"
+)
+
+type BackendConfig struct {
+	// One of http, memory or file.
+	Kind    string        `yaml:"kind"`
+	URL     string        `yaml:"url"`
+	Timeout time.Duration `yaml:"timeout"`
+	// File used by the file backend.
+	File string `yaml:"file"`
+	// Achievements wait in the outbox up to this interval to be posted in a
+	// batch with the ones that follow, or until this many are waiting.
+	AchievementFlushInterval time.Duration `yaml:"achievementFlushInterval"`
+	AchievementBatchSize     int           `yaml:"achievementBatchSize"`
+	Outbox                   OutboxConfig  `yaml:"outbox"`
+}
+
+type OutboxConfig struct {
+	// Directory where queued calls are kept, they are only kept in memory
+	// when empty.
+	Dir string `yaml:"dir"`
+	// Wait after the first failed attempt, doubled after every other one.
+	MinBackoff time.Duration `yaml:"minBackoff"`
+	MaxBackoff time.Duration `yaml:"maxBackoff"`
+	// Calls failing this many times are moved to the dead letters.
+	MaxAttempts int `yaml:"maxAttempts"`
+}
+
+type WebsocketConfig struct {
+	// Largest message accepted from a client, in bytes.
+	MaxMessageSize int64 `yaml:"maxMessageSize"`
+}
+
+type RecordConfig struct {
+	// Directory where replays are written, recording is disabled when empty.
+	Dir         string        `yaml:"dir"`
+	RotateEvery time.Duration `yaml:"rotateEvery"`
+	MaxFileSize int64         `yaml:"maxFileSize"`
+}
+
+type LogConfig struct {
+	// One of debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Either text or json.
+	Format string `yaml:"format"`
+}
+
+// Default returns the settings used when nothing overrides them.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            "4440",
+			ShutdownTimeout: 10 * time.Second,
+		},
+		World: WorldConfig{
+			Width:          10_000,
+			Height:         10_000,
+			Food:           800,
+			MaxPlayers:     100,
+			StartingRadius: 50,
+			MinPlayers:     5,
+
+			SpawnProtection:         3 * time.Second,
+			SpawnProtectionDistance: 300,
+		},
+		Bots: BotConfig{
+			Speed:            10,
+			MaxRange:         1100,
+			PlayerPreference: 500,
+		},
+		Leaderboard: LeaderboardConfig{
+			Interval: time.Second,
+			Size:     10,
+		},
+		Chat: ChatConfig{
+			Private:    true,
+			MaxLength:  200,
+			RateLimit:  5,
+			RatePeriod: 10 * time.Second,
+		},
+		Feed: FeedConfig{
+			Kill:       "{player} ate {victim}",
+			BotEaten:   "bot {victim} was eaten",
+			NewLeader:  "{player} is now #1",
+			Milestone:  "{player} reached radius {radius}",
+			Milestones: []uint32{1000, 2000, 5000},
+		},
+		Director: DirectorConfig{
+			Interval:     500 * time.Millisecond,
+			MinHold:      4 * time.Second,
+			SwitchMargin: 1.5,
+		},
+		Spectators: SpectatorConfig{
+			Private: true,
+			Delay:   30 * time.Second,
+			Max:     50,
+		},
+		Backend: BackendConfig{
+			Kind:    BACKEND_HTTP,
+			URL:     "http://galaxy.t2dc.es:3000",
+			Timeout: 3 * time.Second,
+			File:    "galaxy-backend.json",
+
+			AchievementFlushInterval: 10 * time.Second,
+			AchievementBatchSize:     500,
+			Outbox: OutboxConfig{
+				Dir:         "galaxy-outbox",
+				MinBackoff:  time.Second,
+				MaxBackoff:  5 * time.Minute,
+				MaxAttempts: 20,
+			},
+		},
+		Websocket: WebsocketConfig{
+			MaxMessageSize: 512,
+		},
+		Record: RecordConfig{
+			RotateEvery: time.Hour,
+			MaxFileSize: 64 << 20,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+	}
+}
+
+// setting is a value that can be overridden from the environment and the
+// command line.
+type setting struct {
+	// Flag name, the path of the setting in the file.
+	key   string
+	env   string
+	usage string
+	set   func(value string) error
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{"server.ip", "GALAXY_SERVER_IP", "address to listen on", stringSetter(&c.Server.IP)},
+		{"server.port", "GALAXY_SERVER_PORT", "port to listen on", stringSetter(&c.Server.Port)},
+		{"server.private", "PRIVATE_SERVER", "run as a private server", privateSetter(&c.Server.Private)},
+		{"server.adminToken", "GALAXY_ADMIN_TOKEN", "token of the admin API, disabled when empty", stringSetter(&c.Server.AdminToken)},
+		{"server.shutdownTimeout", "GALAXY_SHUTDOWN_TIMEOUT", "time given to shut down", durationSetter(&c.Server.ShutdownTimeout)},
+		{"world.width", "GALAXY_WORLD_WIDTH", "width of the world", uintSetter(&c.World.Width)},
+		{"world.height", "GALAXY_WORLD_HEIGHT", "height of the world", uintSetter(&c.World.Height)},
+		{"world.food", "GALAXY_WORLD_FOOD", "amount of food in the world", intSetter(&c.World.Food)},
+		{"world.maxPlayers", "GALAXY_WORLD_MAX_PLAYERS", "players allowed at once", intSetter(&c.World.MaxPlayers)},
+		{"world.startingRadius", "GALAXY_WORLD_STARTING_RADIUS", "radius of new players", uintSetter(&c.World.StartingRadius)},
+		{"world.minPlayers", "GALAXY_WORLD_MIN_PLAYERS", "bots are added below this many players", intSetter(&c.World.MinPlayers)},
+		{"world.seed", "GALAXY_SEED", "seed of the world, random when zero", uint64Setter(&c.World.Seed)},
+		{"world.spawnProtection", "GALAXY_WORLD_SPAWN_PROTECTION", "time new players can't eat or be eaten, zero disables it", durationSetter(&c.World.SpawnProtection)},
+		{"world.spawnProtectionDistance", "GALAXY_WORLD_SPAWN_PROTECTION_DISTANCE", "distance moved that ends the spawn protection", uintSetter(&c.World.SpawnProtectionDistance)},
+		{"bots.speed", "GALAXY_BOTS_SPEED", "distance moved by bots every step", uintSetter(&c.Bots.Speed)},
+		{"bots.maxRange", "GALAXY_BOTS_MAX_RANGE", "distance bots look for targets", uintSetter(&c.Bots.MaxRange)},
+		{"bots.playerPreference", "GALAXY_BOTS_PLAYER_PREFERENCE", "distance bots prefer players over food", int32Setter(&c.Bots.PlayerPreference)},
+		{"backend.kind", "GALAXY_BACKEND_KIND", "one of http, memory or file", stringSetter(&c.Backend.Kind)},
+		{"backend.url", "GALAXY_BACKEND_URL", "URL of the backend", stringSetter(&c.Backend.URL)},
+		{"backend.timeout", "GALAXY_BACKEND_TIMEOUT", "timeout of requests to the backend", durationSetter(&c.Backend.Timeout)},
+		{"backend.file", "GALAXY_BACKEND_FILE", "file used by the file backend", stringSetter(&c.Backend.File)},
+		{"backend.achievementFlushInterval", "GALAXY_ACHIEVEMENT_FLUSH_INTERVAL", "longest wait of achievements for a batch", durationSetter(&c.Backend.AchievementFlushInterval)},
+		{"backend.achievementBatchSize", "GALAXY_ACHIEVEMENT_BATCH_SIZE", "most achievements posted in a batch", intSetter(&c.Backend.AchievementBatchSize)},
+		{"backend.outbox.dir", "GALAXY_OUTBOX_DIR", "directory to keep queued backend calls in, memory when empty", stringSetter(&c.Backend.Outbox.Dir)},
+		{"backend.outbox.minBackoff", "GALAXY_OUTBOX_MIN_BACKOFF", "wait after the first failed backend call", durationSetter(&c.Backend.Outbox.MinBackoff)},
+		{"backend.outbox.maxBackoff", "GALAXY_OUTBOX_MAX_BACKOFF", "longest wait between attempts of a backend call", durationSetter(&c.Backend.Outbox.MaxBackoff)},
+		{"backend.outbox.maxAttempts", "GALAXY_OUTBOX_MAX_ATTEMPTS", "attempts before a backend call is given up on", intSetter(&c.Backend.Outbox.MaxAttempts)},
+		{"leaderboard.interval", "GALAXY_LEADERBOARD_INTERVAL", "time between leaderboards sent to the clients", durationSetter(&c.Leaderboard.Interval)},
+		{"leaderboard.size", "GALAXY_LEADERBOARD_SIZE", "players listed in the leaderboard", intSetter(&c.Leaderboard.Size)},
+		{"chat.public", "GALAXY_CHAT_PUBLIC", "enable the chat in public worlds", boolSetter(&c.Chat.Public)},
+		{"chat.private", "GALAXY_CHAT_PRIVATE", "enable the chat in private worlds", boolSetter(&c.Chat.Private)},
+		{"chat.maxLength", "GALAXY_CHAT_MAX_LENGTH", "longest chat message accepted, in characters", intSetter(&c.Chat.MaxLength)},
+		{"chat.rateLimit", "GALAXY_CHAT_RATE_LIMIT", "chat messages a player can send every rate period", intSetter(&c.Chat.RateLimit)},
+		{"chat.ratePeriod", "GALAXY_CHAT_RATE_PERIOD", "period of the chat rate limit", durationSetter(&c.Chat.RatePeriod)},
+		{"chat.filter", "GALAXY_CHAT_FILTER", "comma separated words filtered out of the chat", listSetter(&c.Chat.Filter)},
+		{"feed.kill", "GALAXY_FEED_KILL", "feed message when a player is eaten, empty to leave it out", stringSetter(&c.Feed.Kill)},
+		{"feed.botEaten", "GALAXY_FEED_BOT_EATEN", "feed message when a bot is eaten, empty to leave it out", stringSetter(&c.Feed.BotEaten)},
+		{"feed.newLeader", "GALAXY_FEED_NEW_LEADER", "feed message when the leader changes, empty to leave it out", stringSetter(&c.Feed.NewLeader)},
+		{"feed.milestone", "GALAXY_FEED_MILESTONE", "feed message when a player reaches a milestone, empty to leave it out", stringSetter(&c.Feed.Milestone)},
+		{"feed.milestones", "GALAXY_FEED_MILESTONES", "comma separated radii announced in the feed", uintListSetter(&c.Feed.Milestones)},
+		{"director.interval", "GALAXY_DIRECTOR_INTERVAL", "time between looks of the spectator camera for something interesting", durationSetter(&c.Director.Interval)},
+		{"director.minHold", "GALAXY_DIRECTOR_MIN_HOLD", "shortest time the spectator camera stays on a player", durationSetter(&c.Director.MinHold)},
+		{"director.switchMargin", "GALAXY_DIRECTOR_SWITCH_MARGIN", "how many times more interesting a shot has to be to switch to it", float64Setter(&c.Director.SwitchMargin)},
+		{"spectators.public", "GALAXY_SPECTATORS_PUBLIC", "delay the spectators of public worlds", boolSetter(&c.Spectators.Public)},
+		{"spectators.private", "GALAXY_SPECTATORS_PRIVATE", "delay the spectators of private worlds", boolSetter(&c.Spectators.Private)},
+		{"spectators.delay", "GALAXY_SPECTATORS_DELAY", "time every event is held back before reaching a spectator", durationSetter(&c.Spectators.Delay)},
+		{"spectators.max", "GALAXY_SPECTATORS_MAX", "connections that only watch allowed at once", intSetter(&c.Spectators.Max)},
+		{"websocket.maxMessageSize", "GALAXY_WEBSOCKET_MAX_MESSAGE_SIZE", "largest message accepted from clients", int64Setter(&c.Websocket.MaxMessageSize)},
+		{"record.dir", "GALAXY_RECORD_DIR", "directory to record matches to, disabled when empty", stringSetter(&c.Record.Dir)},
+		{"record.rotateEvery", "GALAXY_RECORD_ROTATE", "time after which a new replay is started", durationSetter(&c.Record.RotateEvery)},
+		{"record.maxFileSize", "GALAXY_RECORD_MAX_SIZE", "size after which a new replay is started", int64Setter(&c.Record.MaxFileSize)},
+		{"log.level", "GALAXY_LOG_LEVEL", "one of debug, info, warn or error", stringSetter(&c.Log.Level)},
+		{"log.format", "GALAXY_LOG_FORMAT", "either text or json", stringSetter(&c.Log.Format)},
+	}
+}
+
+// Load builds the configuration from the file given by -config or
+// GALAXY_CONFIG, the environment and the flags in args, and validates it.
+// It returns the arguments left after the flags.
+func Load(name string, args []string) (*Config, []string, error) {
+	config := Default()
+	settings := config.settings()
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := flags.String("config", os.Getenv("GALAXY_CONFIG"), "YAML file to read the configuration from (env GALAXY_CONFIG)")
+
+	// flags are applied last, after the file and the environment
+	type override struct {
+		setting setting
+		value   string
+	}
+	var overrides []override
+	for _, s := range settings {
+		flags.Func(s.key, s.usage+" (env "+s.env+")", func(value string) error {
+			overrides = append(overrides, override{s, value})
+			return nil
+		})
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *path != "" {
+		if err := config.loadFile(*path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range settings {
+		value, exists := os.LookupEnv(s.env)
+		if !exists {
+			continue
+		}
+		if err := s.set(value); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", s.env, err)
+		}
+	}
+
+	for _, o := range overrides {
+		if err := o.setting.set(o.value); err != nil {
+			return nil, nil, fmt.Errorf("invalid -%s: %w", o.setting.key, err)
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return config, flags.Args(), nil
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open config: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unable to parse config %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every setting with a value the server can't run with.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+	check(c.World.Width > 0 && c.World.Height > 0, "world size must be positive, got %dx%d", c.World.Width, c.World.Height)
+	check(c.World.Food >= 0, "world.food can't be negative")
+	check(c.World.MaxPlayers > 0, "world.maxPlayers must be positive")
+	check(c.World.StartingRadius > 0, "world.startingRadius must be positive")
+	check(c.World.MinPlayers >= 0, "world.minPlayers can't be negative")
+	check(c.World.SpawnProtection >= 0, "world.spawnProtection can't be negative")
+	check(c.Bots.Speed > 0, "bots.speed must be positive")
+	check(c.Backend.Timeout > 0, "backend.timeout must be positive")
+	check(c.Backend.AchievementFlushInterval > 0, "backend.achievementFlushInterval must be positive")
+	check(c.Backend.AchievementBatchSize > 0, "backend.achievementBatchSize must be positive")
+	check(c.Backend.Outbox.MinBackoff > 0, "backend.outbox.minBackoff must be positive")
+	check(c.Backend.Outbox.MaxBackoff >= c.Backend.Outbox.MinBackoff, "backend.outbox.maxBackoff can't be shorter than minBackoff")
+	check(c.Backend.Outbox.MaxAttempts > 0, "backend.outbox.maxAttempts must be positive")
+	check(c.Leaderboard.Interval > 0, "leaderboard.interval must be positive")
+	check(c.Leaderboard.Size > 0, "leaderboard.size must be positive")
+	check(c.Chat.MaxLength > 0, "chat.maxLength must be positive")
+	check(c.Chat.RateLimit > 0, "chat.rateLimit must be positive")
+	check(c.Chat.RatePeriod > 0, "chat.ratePeriod must be positive")
+	check(c.Director.Interval > 0, "director.interval must be positive")
+	check(c.Director.MinHold >= 0, "director.minHold can't be negative")
+	check(c.Director.SwitchMargin >= 1, "director.switchMargin can't be below 1")
+	check(c.Spectators.Delay >= 0, "spectators.delay can't be negative")
+	check(c.Spectators.Max > 0, "spectators.max must be positive")
+	check(c.Websocket.MaxMessageSize > 0, "websocket.maxMessageSize must be positive")
+	check(c.Record.RotateEvery > 0, "record.rotateEvery must be positive")
+	check(c.Record.MaxFileSize > 0, "record.maxFileSize must be positive")
+
+	switch c.Backend.Kind {
+	case BACKEND_HTTP:
+		backendURL, err := url.Parse(c.Backend.URL)
+		check(err == nil && backendURL.Scheme != "" && backendURL.Host != "", "backend.url must be an absolute URL, got %q", c.Backend.URL)
+	case BACKEND_MEMORY:
+	case BACKEND_FILE:
+		check(c.Backend.File != "", "backend.file is required by the file backend")
+	default:
+		check(false, "backend.kind must be one of http, memory or file, got %q", c.Backend.Kind)
+	}
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level must be one of debug, info, warn or error, got %q", c.Log.Level)
+	format := strings.ToLower(c.Log.Format)
+	check(format == "text" || format == "json", "log.format must be text or json, got %q", c.Log.Format)
+
+	return errors.Join(errs...)
+}
+
+func stringSetter(p *string) func(string) error {
+	return func(value string) error {
+		*p = value
+		return nil
+	}
+}
+
+// listSetter splits a comma separated list, ignoring empty items.
+func listSetter(p *[]string) func(string) error {
+	return func(value string) error {
+		*p = nil
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*p = append(*p, item)
+			}
+		}
+		return nil
+	}
+}
+
+func uintListSetter(p *[]uint32) func(string) error {
+	return func(value string) error {
+		var items []string
+		if err := listSetter(&items)(value); err != nil {
+			return err
+		}
+		*p = nil
+		for _, item := range items {
+			parsed, err := strconv.ParseUint(item, 10, 32)
+			if err != nil {
+				return err
+			}
+			*p = append(*p, uint32(parsed))
+		}
+		return nil
+	}
+}
+
+func boolSetter(p *bool) func(string) error {
+	return func(value string) error {
+		switch strings.ToLower(value) {
+		case "true", "1", "yes":
+			*p = true
+		case "false", "0", "no":
+			*p = false
+		default:
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		return nil
+	}
+}
+
+// privateSetter is a boolSetter that starts a public server when the value
+// isn't a boolean, as PRIVATE_SERVER always did, instead of failing.
+func privateSetter(p *bool) func(string) error {
+	set := boolSetter(p)
+	return func(value string) error {
+		if err := set(value); err != nil {
+			slog.Warn("no clue if you want a private server, going public", "value", value)
+			*p = false
+		}
+		return nil
+	}
+}
+
+func intSetter(p *int) func(string) error {
+	return func(value string) error {
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*p = i
+		return nil
+	}
+}
+
+func int32Setter(p *int32) func(string) error {
+	return func(value string) error {
+		i, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		*p = int32(i)
+		return nil
+	}
+}
+
+func int64Setter(p *int64) func(string) error {
+	return func(value string) error {
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*p = i
+		return nil
+	}
+}
+
+func uintSetter(p *uint32) func(string) error {
+	return func(value string) error {
+		u, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		*p = uint32(u)
+		return nil
+	}
+}
+
+func uint64Setter(p *uint64) func(string) error {
+	return func(value string) error {
+		u, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*p = u
+		return nil
+	}
+}
+
+func float64Setter(p *float64) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*p = parsed
+		return nil
+	}
+}
+
+func durationSetter(p *time.Duration) func(string) error {
+	return func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*p = d
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import "testing"
+
+func TestPrivateServer(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"yes", true},
+		{"TRUE", true},
+		{"1", true},
+		{"no", false},
+		{"0", false},
+		{"maybe", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv("PRIVATE_SERVER", test.value)
+			config, _, err := Load("galaxy", nil)
+			if err != nil {
+				t.Fatalf("got error %v, want a public server", err)
+			}
+			if config.Server.Private != test.want {
+				t.Errorf("got private %v, want %v", config.Server.Private, test.want)
+			}
+		})
+	}
+}
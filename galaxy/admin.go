@@ -0,0 +1,538 @@
+package galaxy
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+// PlayerInfo describes a player in the admin API.
+type PlayerInfo struct {
+	PlayerID     string     `json:"playerId"`
+	ConnectionID string     `json:"connectionId,omitempty"`
+	Username     string     `json:"username"`
+	Bot          bool       `json:"bot"`
+	X            uint32     `json:"x"`
+	Y            uint32     `json:"y"`
+	Radius       uint32     `json:"radius"`
+	RemoteAddr   string     `json:"remoteAddr,omitempty"`
+	ConnectedAt  *time.Time `json:"connectedAt,omitempty"`
+}
+
+// WorldStats describes a world in the admin API.
+type WorldStats struct {
+	Humans        int     `json:"humans"`
+	Bots          int     `json:"bots"`
+	Spectators    int     `json:"spectators"`
+	Food          int     `json:"food"`
+	PrivateServer bool    `json:"privateServer"`
+	GameID        *uint32 `json:"gameId,omitempty"`
+	Seed          uint64  `json:"seed"`
+	Banned        int     `json:"banned"`
+}
+
+type CountRequest struct {
+	Count int `json:"count"`
+}
+
+type AnnounceRequest struct {
+	Message string `json:"message"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+var (
+	ErrorPlayerNotFound = errors.New("player not found")
+	ErrorNotABot        = errors.New("player is not a bot")
+	ErrorWrongGame      = errors.New("no private game with that gameID is running")
+)
+
+// AdminHandler returns the /admin API of the world. Every request must carry
+// the token as "Authorization: Bearer <token>".
+func (w *World) AdminHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /admin/world", w.adminWorldStats)
+	mux.HandleFunc("GET /admin/players", w.adminListPlayers)
+	mux.HandleFunc("POST /admin/players/{id}/kick", w.adminKickPlayer)
+	mux.HandleFunc("POST /admin/players/{id}/ban", w.adminBanPlayer)
+	mux.HandleFunc("GET /admin/bans", w.adminListBans)
+	mux.HandleFunc("DELETE /admin/bans/{id}", w.adminUnbanPlayer)
+	mux.HandleFunc("POST /admin/players/{id}/mute", w.adminMutePlayer)
+	mux.HandleFunc("GET /admin/mutes", w.adminListMutes)
+	mux.HandleFunc("DELETE /admin/mutes/{id}", w.adminUnmutePlayer)
+	mux.HandleFunc("POST /admin/bots", w.adminAddBots)
+	mux.HandleFunc("DELETE /admin/bots/{id}", w.adminRemoveBot)
+	mux.HandleFunc("PUT /admin/food", w.adminSetFood)
+	mux.HandleFunc("POST /admin/private/{gameID}/save", w.adminSavePrivateGame)
+	mux.HandleFunc("POST /admin/private/{gameID}/pause", w.adminPausePrivateGame)
+	mux.HandleFunc("POST /admin/announce", w.adminAnnounce)
+	mux.HandleFunc("GET /admin/outbox", w.adminOutboxStatus)
+	mux.HandleFunc("POST /admin/outbox/{id}/retry", w.adminRetryOutboxEntry)
+	mux.HandleFunc("DELETE /admin/outbox/{id}", w.adminDiscardOutboxEntry)
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		given, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			writeJSON(writer, http.StatusUnauthorized, ErrorResponse{Error: "invalid admin token"})
+			return
+		}
+		mux.ServeHTTP(writer, r)
+	})
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		slog.Error("error writing admin response", "err", err)
+	}
+}
+
+func writeError(writer http.ResponseWriter, status int, err error) {
+	writeJSON(writer, status, ErrorResponse{Error: err.Error()})
+}
+
+func (w *World) adminWorldStats(writer http.ResponseWriter, r *http.Request) {
+	writeJSON(writer, http.StatusOK, w.Stats())
+}
+
+func (w *World) adminListPlayers(writer http.ResponseWriter, r *http.Request) {
+	writeJSON(writer, http.StatusOK, w.Players())
+}
+
+func (w *World) adminKickPlayer(writer http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := w.Kick(playerID); err != nil {
+		writeError(writer, http.StatusNotFound, err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminBanPlayer(writer http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Ban(playerID)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminListBans(writer http.ResponseWriter, r *http.Request) {
+	writeJSON(writer, http.StatusOK, w.Bans())
+}
+
+func (w *World) adminUnbanPlayer(writer http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Unban(playerID)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminMutePlayer(writer http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Mute(playerID)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminListMutes(writer http.ResponseWriter, r *http.Request) {
+	writeJSON(writer, http.StatusOK, w.Mutes())
+}
+
+func (w *World) adminUnmutePlayer(writer http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Unmute(playerID)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminAddBots(writer http.ResponseWriter, r *http.Request) {
+	var request CountRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if request.Count <= 0 {
+		writeError(writer, http.StatusBadRequest, errors.New("count must be positive"))
+		return
+	}
+
+	var bots []PlayerInfo
+	for range request.Count {
+		bot := w.spawnBot()
+		bots = append(bots, playerInfo(bot.player))
+	}
+	writeJSON(writer, http.StatusCreated, bots)
+}
+
+func (w *World) adminRemoveBot(writer http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	err = w.RemoveBot(playerID)
+	switch {
+	case errors.Is(err, ErrorPlayerNotFound):
+		writeError(writer, http.StatusNotFound, err)
+	case err != nil:
+		writeError(writer, http.StatusBadRequest, err)
+	default:
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (w *World) adminSetFood(writer http.ResponseWriter, r *http.Request) {
+	var request CountRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if request.Count < 0 {
+		writeError(writer, http.StatusBadRequest, errors.New("count can't be negative"))
+		return
+	}
+
+	w.SetFoodCount(request.Count)
+	writeJSON(writer, http.StatusOK, w.Stats())
+}
+
+func (w *World) adminSavePrivateGame(writer http.ResponseWriter, r *http.Request) {
+	gameID, err := strconv.ParseUint(r.PathValue("gameID"), 10, 32)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := w.SavePrivateGame(uint32(gameID)); err != nil {
+		writeError(writer, http.StatusNotFound, err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminPausePrivateGame(writer http.ResponseWriter, r *http.Request) {
+	gameID, err := strconv.ParseUint(r.PathValue("gameID"), 10, 32)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := w.PausePrivateGame(uint32(gameID)); err != nil {
+		writeError(writer, http.StatusNotFound, err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminAnnounce(writer http.ResponseWriter, r *http.Request) {
+	var request AnnounceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if request.Message == "" {
+		writeError(writer, http.StatusBadRequest, errors.New("empty message"))
+		return
+	}
+
+	w.Announce(request.Message)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminOutboxStatus(writer http.ResponseWriter, r *http.Request) {
+	writeJSON(writer, http.StatusOK, w.outbox.Status())
+}
+
+func (w *World) adminRetryOutboxEntry(writer http.ResponseWriter, r *http.Request) {
+	if err := w.outbox.Retry(r.PathValue("id")); err != nil {
+		writeError(writer, http.StatusNotFound, err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (w *World) adminDiscardOutboxEntry(writer http.ResponseWriter, r *http.Request) {
+	if err := w.outbox.Discard(r.PathValue("id")); err != nil {
+		writeError(writer, http.StatusNotFound, err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func playerInfo(player *Player) PlayerInfo {
+	position := player.GetPosition()
+	info := PlayerInfo{
+		PlayerID:   player.PlayerID.String(),
+		Username:   player.Username,
+		Bot:        player.IsBot(),
+		X:          position.X,
+		Y:          position.Y,
+		Radius:     player.Radius,
+		RemoteAddr: player.RemoteAddr,
+	}
+	if !player.IsBot() {
+		info.ConnectionID = player.ConnectionID.String()
+		info.ConnectedAt = &player.ConnectedAt
+	}
+	return info
+}
+
+// Players returns every player and bot currently in the world.
+func (w *World) Players() []PlayerInfo {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+
+	players := []PlayerInfo{}
+	for _, player := range w.players {
+		players = append(players, playerInfo(player))
+	}
+	return players
+}
+
+func (w *World) Stats() WorldStats {
+	stats := WorldStats{
+		PrivateServer: w.privateServer,
+		GameID:        w.gameID,
+		Seed:          w.rng.Seed(),
+	}
+
+	w.playersMutex.RLock()
+	for _, player := range w.players {
+		if player.IsBot() {
+			stats.Bots++
+		} else {
+			stats.Humans++
+		}
+	}
+	stats.Spectators = len(w.spectators)
+	stats.Banned = len(w.banned)
+	w.playersMutex.RUnlock()
+
+	w.foodMutex.RLock()
+	stats.Food = len(w.food)
+	w.foodMutex.RUnlock()
+
+	return stats
+}
+
+// Kick removes a player from the world, closing its connection. Eaten
+// players that are still spectating can be kicked too.
+func (w *World) Kick(playerID uuid.UUID) error {
+	player, exists := w.connectedPlayer(playerID)
+	if !exists {
+		return ErrorPlayerNotFound
+	}
+
+	player.Logger().Info("kicking player")
+	w.removePlayer(player)
+	return nil
+}
+
+// Ban kicks a player if it is online and stops it from joining again.
+func (w *World) Ban(playerID uuid.UUID) {
+	slog.Info("banning player", "playerID", playerID)
+	w.playersMutex.Lock()
+	w.banned[playerID] = true
+	w.playersMutex.Unlock()
+
+	w.Kick(playerID)
+}
+
+func (w *World) Unban(playerID uuid.UUID) {
+	slog.Info("unbanning player", "playerID", playerID)
+	w.playersMutex.Lock()
+	delete(w.banned, playerID)
+	w.playersMutex.Unlock()
+}
+
+func (w *World) Bans() []string {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+
+	bans := []string{}
+	for playerID := range w.banned {
+		bans = append(bans, playerID.String())
+	}
+	return bans
+}
+
+func (w *World) isBanned(playerID uuid.UUID) bool {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+	return w.banned[playerID]
+}
+
+// Mute stops a player from sending chat messages.
+func (w *World) Mute(playerID uuid.UUID) {
+	slog.Info("muting player", "playerID", playerID)
+	w.playersMutex.Lock()
+	w.muted[playerID] = true
+	w.playersMutex.Unlock()
+}
+
+func (w *World) Unmute(playerID uuid.UUID) {
+	slog.Info("unmuting player", "playerID", playerID)
+	w.playersMutex.Lock()
+	delete(w.muted, playerID)
+	w.playersMutex.Unlock()
+}
+
+func (w *World) Mutes() []string {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+
+	mutes := []string{}
+	for playerID := range w.muted {
+		mutes = append(mutes, playerID.String())
+	}
+	return mutes
+}
+
+func (w *World) isMuted(playerID uuid.UUID) bool {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+	return w.muted[playerID]
+}
+
+// RemoveBot despawns a bot, stopping its goroutine.
+func (w *World) RemoveBot(playerID uuid.UUID) error {
+	w.playersMutex.RLock()
+	player, exists := w.players[playerID]
+	w.playersMutex.RUnlock()
+
+	if !exists {
+		return ErrorPlayerNotFound
+	}
+	if !player.IsBot() {
+		return ErrorNotABot
+	}
+
+	player.Logger().Info("despawning bot")
+	w.removePlayer(player)
+	return nil
+}
+
+// SetFoodCount adds or removes food until the world holds count items. The
+// changes are broadcast once the food lock is released, as a failing
+// receiver is removed from the world on the spot.
+func (w *World) SetFoodCount(count int) {
+	var events []*pb.Event
+
+	w.foodMutex.Lock()
+	slog.Info("changing food count", "from", len(w.food), "to", count)
+
+	if count < len(w.food) {
+		removed := w.food[count:]
+		w.food = w.food[:count:count]
+
+		for _, food := range removed {
+			events = append(events, &pb.Event{
+				EventType: pb.EventType_EvDestroyFood.Enum(),
+				EventData: &pb.Event_DestroyFoodEvent{
+					DestroyFoodEvent: &pb.DestroyFoodEvent{
+						Position: food.position.toPacket(),
+					},
+				},
+			})
+		}
+	}
+
+	var pbFoods []*pb.Food
+	for len(w.food) < count {
+		food := Food{
+			position: *randomPosition(w.rng, w.config.World),
+			color:    randomColor(w.rng),
+		}
+		w.food = append(w.food, food)
+		pbFoods = append(pbFoods, &pb.Food{
+			Position: food.position.toPacket(),
+			Color:    &food.color,
+		})
+	}
+	if len(pbFoods) > 0 {
+		events = append(events, &pb.Event{
+			EventType: pb.EventType_EvNewFood.Enum(),
+			EventData: &pb.Event_NewFoodEvent{
+				NewFoodEvent: &pb.NewFoodEvent{
+					Food: pbFoods,
+				},
+			},
+		})
+	}
+	w.foodMutex.Unlock()
+
+	for _, event := range events {
+		w.broadcastEvent(event)
+	}
+}
+
+// SavePrivateGame uploads the state of the running private game without
+// stopping it.
+func (w *World) SavePrivateGame(gameID uint32) error {
+	if current := w.gameID; current == nil || *current != gameID {
+		return ErrorWrongGame
+	}
+
+	slog.Info("saving private game", "gameID", gameID)
+	w.playersMutex.RLock()
+	w.uploadValues(gameID)
+	w.playersMutex.RUnlock()
+	return nil
+}
+
+// PausePrivateGame pauses the running private game, the same way a player
+// sending a PauseOperation does.
+func (w *World) PausePrivateGame(gameID uint32) error {
+	if current := w.gameID; current == nil || *current != gameID {
+		return ErrorWrongGame
+	}
+
+	w.pauseServer()
+	return nil
+}
+
+// Announce broadcasts a message from the administrators to every player.
+func (w *World) Announce(message string) {
+	slog.Info("broadcasting announcement", "message", message)
+	w.broadcastEvent(&pb.Event{
+		EventType: pb.EventType_EvAnnouncement.Enum(),
+		EventData: &pb.Event_AnnouncementEvent{
+			AnnouncementEvent: &pb.AnnouncementEvent{
+				Message: &message,
+			},
+		},
+	})
+}
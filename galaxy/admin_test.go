@@ -0,0 +1,184 @@
+package galaxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+const testAdminToken = "secret"
+
+// adminRequest serves a request to the admin API of the world, moving the
+// clock along for the handlers that sleep on it.
+func adminRequest(t *testing.T, w *World, clock *ManualClock, token string, method string, path string, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	served := run(func() { w.AdminHandler(testAdminToken).ServeHTTP(recorder, request) })
+	advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, served)
+	return recorder
+}
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{"without a token", "", http.StatusUnauthorized},
+		{"with the wrong token", "guess", http.StatusUnauthorized},
+		{"with the token", testAdminToken, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, nil)
+			response := adminRequest(t, w, clock, test.token, http.MethodGet, "/admin/world", "")
+			if response.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d", response.Code, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAdminPlayers(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		// Path of the request, with {id} replaced by the ID of a player.
+		path       string
+		wantStatus int
+		// Whether the player was kicked and banned.
+		wantKicked bool
+		wantBanned bool
+	}{
+		{"kick", http.MethodPost, "/admin/players/{id}/kick", http.StatusNoContent, true, false},
+		{"kick someone not online", http.MethodPost, "/admin/players/" + uuid.NewString() + "/kick", http.StatusNotFound, false, false},
+		{"kick with a bad ID", http.MethodPost, "/admin/players/nobody/kick", http.StatusBadRequest, false, false},
+		{"ban", http.MethodPost, "/admin/players/{id}/ban", http.StatusNoContent, true, true},
+		{"ban with a bad ID", http.MethodPost, "/admin/players/nobody/ban", http.StatusBadRequest, false, false},
+		{"remove a human as a bot", http.MethodDelete, "/admin/bots/{id}", http.StatusBadRequest, false, false},
+		{"remove a bot not online", http.MethodDelete, "/admin/bots/" + uuid.NewString(), http.StatusNotFound, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.MinPlayers = 0
+			})
+			player, conn := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+
+			path := strings.ReplaceAll(test.path, "{id}", player.PlayerID.String())
+			response := adminRequest(t, w, clock, testAdminToken, test.method, path, "")
+			if response.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d: %s", response.Code, test.wantStatus, response.Body)
+			}
+			if kicked := conn.isClosed(); kicked != test.wantKicked {
+				t.Errorf("got kicked %v, want %v", kicked, test.wantKicked)
+			}
+			if banned := w.isBanned(player.PlayerID); banned != test.wantBanned {
+				t.Errorf("got banned %v, want %v", banned, test.wantBanned)
+			}
+		})
+	}
+}
+
+func TestAdminSetFood(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFood   int
+		// Number of food events broadcast.
+		wantDestroyed int
+		wantNew       int
+	}{
+		{"fewer", `{"count": 4}`, http.StatusOK, 4, 6, 0},
+		{"more", `{"count": 25}`, http.StatusOK, 25, 0, 1},
+		{"the same", `{"count": 10}`, http.StatusOK, 10, 0, 0},
+		{"negative", `{"count": -1}`, http.StatusBadRequest, 10, 0, 0},
+		{"not JSON", `four`, http.StatusBadRequest, 10, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.Food = 10
+			})
+			_, conn := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+
+			response := adminRequest(t, w, clock, testAdminToken, http.MethodPut, "/admin/food", test.body)
+			if response.Code != test.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", response.Code, test.wantStatus, response.Body)
+			}
+			if food := w.Stats().Food; food != test.wantFood {
+				t.Errorf("got %d food, want %d", food, test.wantFood)
+			}
+			if test.wantStatus == http.StatusOK {
+				var stats WorldStats
+				if err := json.NewDecoder(response.Body).Decode(&stats); err != nil {
+					t.Fatal(err)
+				}
+				if stats.Food != test.wantFood {
+					t.Errorf("responded with %d food, want %d", stats.Food, test.wantFood)
+				}
+			}
+			if destroyed := len(conn.received(pb.EventType_EvDestroyFood)); destroyed != test.wantDestroyed {
+				t.Errorf("got %d destroyed food events, want %d", destroyed, test.wantDestroyed)
+			}
+			if added := len(conn.received(pb.EventType_EvNewFood)); added != test.wantNew {
+				t.Errorf("got %d new food events, want %d", added, test.wantNew)
+			}
+		})
+	}
+}
+
+func TestAdminPrivateGame(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"save another game", "/admin/private/2/save", http.StatusNotFound},
+		{"pause another game", "/admin/private/2/pause", http.StatusNotFound},
+		{"save with a bad game ID", "/admin/private/two/save", http.StatusBadRequest},
+		{"save the running game", "/admin/private/1/save", http.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, nil)
+			gameID := uint32(1)
+			w.gameID = &gameID
+
+			response := adminRequest(t, w, clock, testAdminToken, http.MethodPost, test.path, "")
+			if response.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d: %s", response.Code, test.wantStatus, response.Body)
+			}
+		})
+	}
+}
+
+func TestAdminOutboxNotFound(t *testing.T) {
+	w, clock, _ := newTestWorld(t, nil)
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		path := "/admin/outbox/missing"
+		if method == http.MethodPost {
+			path += "/retry"
+		}
+		response := adminRequest(t, w, clock, testAdminToken, method, path, "")
+		if response.Code != http.StatusNotFound {
+			t.Errorf("%s %s: got status %d, want %d", method, path, response.Code, http.StatusNotFound)
+		}
+	}
+}
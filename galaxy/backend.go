@@ -0,0 +1,260 @@
+package galaxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"galaxy.io/server/config"
+)
+
+// Kinds of achievement posted for every player that leaves a game.
+const (
+	// Peak radius.
+	ACHIEVEMENT_MAX_SCORE          = "maxScore"
+	ACHIEVEMENT_PLAYERS_ELIMINATED = "playersEliminated"
+	// Seconds.
+	ACHIEVEMENT_TIME_PLAYED        = "timePlayed"
+	ACHIEVEMENT_FOOD_EATEN         = "foodEaten"
+	ACHIEVEMENT_DISTANCE_TRAVELLED = "distanceTravelled"
+	// Seconds of the longest life.
+	ACHIEVEMENT_LONGEST_LIFE = "longestLife"
+	// Most players eliminated in a single life.
+	ACHIEVEMENT_KILL_STREAK = "longestKillStreak"
+	// Seconds spent first in the leaderboard.
+	ACHIEVEMENT_TIME_AT_TOP = "timeAtTop"
+	// Posted with the killer as OtherUserID.
+	ACHIEVEMENT_KILLED_BY = "killedBy"
+)
+
+// Backend stores what outlives a game: saved private games and the
+// achievements of the players.
+//
+// Calls may be retried, implementations should use the key returned by
+// IdempotencyKey to apply a call only once.
+type Backend interface {
+	StartPrivateGame(ctx context.Context, gameID uint32) error
+	PausePrivateGame(ctx context.Context, gameID uint32) error
+	// GetValues returns the players saved for a private game.
+	GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error)
+	UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error
+	PostAchievements(ctx context.Context, achievements []Achievement) error
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context carrying the key identifying a call,
+// which stays the same every time the call is retried.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the key identifying the call, empty if it has none.
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+type PlayerData struct {
+	PlayerID string `json:"id_user"`
+	X        uint32 `json:"x_position"`
+	Y        uint32 `json:"y_position"`
+	Score    uint32 `json:"score"`
+}
+
+type Achievement struct {
+	UserID   string `json:"user_id"`
+	Kind     string `json:"achievement_type"`
+	Quantity uint32 `json:"quantity"`
+	// Other player involved, for the kinds that have one.
+	OtherUserID string `json:"other_user_id,omitempty"`
+}
+
+// AchievementsError is returned by PostAchievements when some of the
+// achievements were not posted, only the ones in Failed have to be retried.
+type AchievementsError struct {
+	Failed []Achievement
+	Err    error
+}
+
+func (e *AchievementsError) Error() string {
+	return fmt.Sprintf("%d achievements not posted: %v", len(e.Failed), e.Err)
+}
+
+func (e *AchievementsError) Unwrap() error {
+	return e.Err
+}
+
+// NewBackend creates the backend selected by the configuration.
+func NewBackend(backend config.BackendConfig) (Backend, error) {
+	switch backend.Kind {
+	case config.BACKEND_HTTP:
+		return NewHTTPBackend(backend), nil
+	case config.BACKEND_MEMORY:
+		return NewMemoryBackend(), nil
+	case config.BACKEND_FILE:
+		return NewFileBackend(backend.File)
+	default:
+		return nil, fmt.Errorf("unknown backend %q", backend.Kind)
+	}
+}
+
+// CallStatus is the outcome of the last call of a kind to the backend.
+type CallStatus struct {
+	At    time.Time `json:"at"`
+	OK    bool      `json:"ok"`
+	Error string    `json:"error,omitempty"`
+}
+
+// observedBackend records the latency and outcome of every call made to the
+// backend it wraps.
+type observedBackend struct {
+	backend Backend
+
+	callsMutex sync.Mutex
+	lastCalls  map[string]CallStatus
+}
+
+func newObservedBackend(backend Backend) *observedBackend {
+	return &observedBackend{
+		backend:   backend,
+		lastCalls: make(map[string]CallStatus),
+	}
+}
+
+func (o *observedBackend) observe(call string, start time.Time, err error) error {
+	backendDuration.With(call).ObserveSince(start)
+
+	status := CallStatus{
+		At: start,
+		OK: err == nil,
+	}
+	if err != nil {
+		status.Error = err.Error()
+		backendFailures.With(call).Inc()
+	}
+
+	o.callsMutex.Lock()
+	o.lastCalls[call] = status
+	o.callsMutex.Unlock()
+	return err
+}
+
+// LastCalls returns the outcome of the last call of every kind made to the
+// backend.
+func (o *observedBackend) LastCalls() map[string]CallStatus {
+	o.callsMutex.Lock()
+	defer o.callsMutex.Unlock()
+
+	calls := make(map[string]CallStatus, len(o.lastCalls))
+	for call, status := range o.lastCalls {
+		calls[call] = status
+	}
+	return calls
+}
+
+func (o *observedBackend) StartPrivateGame(ctx context.Context, gameID uint32) error {
+	start := time.Now()
+	return o.observe("startPrivateGame", start, o.backend.StartPrivateGame(ctx, gameID))
+}
+
+func (o *observedBackend) PausePrivateGame(ctx context.Context, gameID uint32) error {
+	start := time.Now()
+	return o.observe("pausePrivateGame", start, o.backend.PausePrivateGame(ctx, gameID))
+}
+
+func (o *observedBackend) GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error) {
+	start := time.Now()
+	players, err := o.backend.GetValues(ctx, gameID)
+	return players, o.observe("getValues", start, err)
+}
+
+func (o *observedBackend) UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error {
+	start := time.Now()
+	return o.observe("uploadValues", start, o.backend.UploadValues(ctx, gameID, players))
+}
+
+func (o *observedBackend) PostAchievements(ctx context.Context, achievements []Achievement) error {
+	start := time.Now()
+	return o.observe("postAchievements", start, o.backend.PostAchievements(ctx, achievements))
+}
+
+// startPrivateGame tells the backend a private game started and loads the
+// players saved for it.
+func (w *World) startPrivateGame(gameID uint32) {
+	if err := w.outbox.StartPrivateGame(context.Background(), gameID); err != nil {
+		slog.Error("error starting private game", "gameID", gameID, "err", err)
+	}
+
+	// the players are loaded once the start is delivered, waiting at most
+	// as long as a call to the backend
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.Backend.Timeout)
+	defer cancel()
+	players, err := w.outbox.GetValues(ctx, gameID)
+	if err != nil {
+		slog.Error("error getting private game", "gameID", gameID, "err", err)
+		w.savedPlayers = nil
+		return
+	}
+
+	slog.Info("got private game from backend", "gameID", gameID, "players", len(players))
+	for _, player := range players {
+		slog.Debug("player gotten from private game", "gameID", gameID, "playerID", player.PlayerID, "x", player.X, "y", player.Y, "score", player.Score)
+	}
+	w.savedPlayers = players
+}
+
+// savePrivateGame pauses the private game in the backend and uploads its
+// players, the caller must hold the players lock.
+func (w *World) savePrivateGame(gameID uint32) {
+	if err := w.outbox.PausePrivateGame(context.Background(), gameID); err != nil {
+		slog.Error("error pausing private game", "gameID", gameID, "err", err)
+	}
+	w.uploadValues(gameID)
+}
+
+// uploadValues uploads the players of the private game, the caller must hold
+// the players lock.
+func (w *World) uploadValues(gameID uint32) {
+	slog.Info("uploading match to backend", "gameID", gameID, "players", len(w.players))
+	var players []PlayerData
+	for _, player := range w.players {
+		position := player.GetPosition()
+		players = append(players, PlayerData{
+			PlayerID: player.PlayerID.String(),
+			X:        position.X,
+			Y:        position.Y,
+			Score:    uint32(player.Radius / 10),
+		})
+	}
+
+	if err := w.outbox.UploadValues(context.Background(), gameID, players); err != nil {
+		slog.Error("error uploading match", "gameID", gameID, "err", err)
+	}
+}
+
+// postAchievements queues the stats of a player that left the game for the
+// next batch of achievements.
+func (w *World) postAchievements(player *Player) {
+	player.Stats.Lock()
+	stats := &player.Stats
+	userID := player.PlayerID.String()
+	achievements := []Achievement{
+		{UserID: userID, Kind: ACHIEVEMENT_MAX_SCORE, Quantity: stats.PeakRadius},
+		{UserID: userID, Kind: ACHIEVEMENT_PLAYERS_ELIMINATED, Quantity: stats.KilledPlayers},
+		{UserID: userID, Kind: ACHIEVEMENT_TIME_PLAYED, Quantity: uint32(stats.TimeEnd.Sub(stats.TimeStart).Seconds())},
+		{UserID: userID, Kind: ACHIEVEMENT_FOOD_EATEN, Quantity: stats.FoodEaten},
+		{UserID: userID, Kind: ACHIEVEMENT_DISTANCE_TRAVELLED, Quantity: uint32(stats.DistanceTravelled)},
+		{UserID: userID, Kind: ACHIEVEMENT_LONGEST_LIFE, Quantity: uint32(stats.longestLife().Seconds())},
+		{UserID: userID, Kind: ACHIEVEMENT_KILL_STREAK, Quantity: stats.LongestKillStreak},
+		{UserID: userID, Kind: ACHIEVEMENT_TIME_AT_TOP, Quantity: uint32(stats.TimeAtTop.Seconds())},
+	}
+	if stats.KilledBy != nil {
+		achievements = append(achievements, Achievement{UserID: userID, Kind: ACHIEVEMENT_KILLED_BY, Quantity: 1, OtherUserID: stats.KilledBy.String()})
+	}
+	player.Stats.Unlock()
+
+	w.outbox.PostAchievements(context.Background(), achievements)
+}
@@ -0,0 +1,114 @@
+package galaxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileBackend keeps everything in a local JSON file, so the server can be
+// developed against without the production API.
+type FileBackend struct {
+	// Held across every change and the save that follows, so saves are
+	// written in order.
+	mutex  sync.Mutex
+	memory *MemoryBackend
+	path   string
+}
+
+// NewFileBackend loads the backend stored at path, starting empty if the
+// file doesn't exist yet.
+func NewFileBackend(path string) (*FileBackend, error) {
+	f := &FileBackend{
+		memory: NewMemoryBackend(),
+		path:   path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &f.memory.state); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	if f.memory.state.Games == nil {
+		f.memory.state.Games = make(map[uint32]*savedGame)
+	}
+	if f.memory.state.PostedKeys == nil {
+		f.memory.state.PostedKeys = make(map[string]bool)
+	}
+	return f, nil
+}
+
+// save writes the state to a temporary file and renames it over the old
+// one, so a crash never leaves a half written file behind.
+func (f *FileBackend) save() error {
+	f.memory.mutex.Lock()
+	data, err := json.MarshalIndent(f.memory.state, "", "  ")
+	f.memory.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), f.path)
+}
+
+// update applies a change and saves the result.
+func (f *FileBackend) update(change func() error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if err := change(); err != nil {
+		return err
+	}
+	return f.save()
+}
+
+func (f *FileBackend) StartPrivateGame(ctx context.Context, gameID uint32) error {
+	return f.update(func() error {
+		return f.memory.StartPrivateGame(ctx, gameID)
+	})
+}
+
+func (f *FileBackend) PausePrivateGame(ctx context.Context, gameID uint32) error {
+	return f.update(func() error {
+		return f.memory.PausePrivateGame(ctx, gameID)
+	})
+}
+
+func (f *FileBackend) GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error) {
+	return f.memory.GetValues(ctx, gameID)
+}
+
+func (f *FileBackend) UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error {
+	return f.update(func() error {
+		return f.memory.UploadValues(ctx, gameID, players)
+	})
+}
+
+func (f *FileBackend) PostAchievements(ctx context.Context, achievements []Achievement) error {
+	return f.update(func() error {
+		return f.memory.PostAchievements(ctx, achievements)
+	})
+}
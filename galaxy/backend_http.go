@@ -0,0 +1,179 @@
+package galaxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"galaxy.io/server/config"
+)
+
+// HTTPBackend is the backend of the production API.
+type HTTPBackend struct {
+	httpClient *http.Client
+	url        string
+
+	// Set once the backend answered that it can't take achievements in
+	// batches, after which they are posted one by one.
+	batchUnsupported atomic.Bool
+}
+
+// errorStatus is returned when the backend answers with a status other than
+// 200.
+type errorStatus struct {
+	path   string
+	code   int
+	status string
+}
+
+func (e *errorStatus) Error() string {
+	return fmt.Sprintf("bad response code from %s: %s", e.path, e.status)
+}
+
+func NewHTTPBackend(backend config.BackendConfig) *HTTPBackend {
+	return &HTTPBackend{
+		httpClient: &http.Client{
+			Timeout: backend.Timeout,
+		},
+		url: strings.TrimSuffix(backend.URL, "/"),
+	}
+}
+
+// post sends data as JSON, failing unless the backend answers with a 200.
+// The idempotency key of the call is sent as the Idempotency-Key header.
+func (d *HTTPBackend) post(ctx context.Context, path string, data any) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %w", path, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key := IdempotencyKey(ctx); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return &errorStatus{path: path, code: resp.StatusCode, status: resp.Status}
+	}
+	return nil
+}
+
+type startPrivateGameData struct {
+	GameID uint32 `json:"gameId"`
+}
+
+func (d *HTTPBackend) StartPrivateGame(ctx context.Context, gameID uint32) error {
+	return d.post(ctx, "/private/startPrivateGame", startPrivateGameData{
+		GameID: gameID,
+	})
+}
+
+type pausePrivateGameData struct {
+	GameID uint32 `json:"gameId"`
+}
+
+func (d *HTTPBackend) PausePrivateGame(ctx context.Context, gameID uint32) error {
+	return d.post(ctx, "/private/pausePrivateGame", pausePrivateGameData{
+		GameID: gameID,
+	})
+}
+
+func (d *HTTPBackend) GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.url+"/private/getValues/"+strconv.FormatUint(uint64(gameID), 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("bad response code from getValues: %s", resp.Status)
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var gameData []PlayerData
+	err = json.Unmarshal(responseBody, &gameData)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling gameData, check proxy: %w", err)
+	}
+
+	return gameData, nil
+}
+
+func (d *HTTPBackend) UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error {
+	return d.post(ctx, "/private/uploadValues/"+strconv.FormatUint(uint64(gameID), 10), players)
+}
+
+// PostAchievements posts all the achievements in a single request. Backends
+// without the batch endpoint get them one by one instead.
+func (d *HTTPBackend) PostAchievements(ctx context.Context, achievements []Achievement) error {
+	if !d.batchUnsupported.Load() {
+		err := d.post(ctx, "/achievements/update-achievements", achievements)
+
+		var status *errorStatus
+		if !errors.As(err, &status) {
+			return err
+		}
+		switch status.code {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			slog.Warn("backend doesn't take achievements in batches, posting them one by one", "code", status.code)
+			d.batchUnsupported.Store(true)
+		default:
+			return err
+		}
+	}
+
+	return d.postAchievementsOneByOne(ctx, achievements)
+}
+
+// postAchievementsOneByOne posts every achievement on its own, going on
+// after the ones that fail, which are returned in an AchievementsError. Each
+// one is sent with the idempotency key of the call followed by its index.
+func (d *HTTPBackend) postAchievementsOneByOne(ctx context.Context, achievements []Achievement) error {
+	key := IdempotencyKey(ctx)
+	var failed []Achievement
+	var errs []error
+	for i, achievement := range achievements {
+		achievementCtx := ctx
+		if key != "" {
+			achievementCtx = WithIdempotencyKey(ctx, key+"-"+strconv.Itoa(i))
+		}
+		err := d.post(achievementCtx, "/achievements/update-achievement", achievement)
+		if err != nil {
+			failed = append(failed, achievement)
+			errs = append(errs, fmt.Errorf("error sending achievement %s of %s: %w", achievement.Kind, achievement.UserID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return &AchievementsError{Failed: failed, Err: errors.Join(errs...)}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package galaxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+)
+
+// achievementServer is a backend without the batch endpoint that fails the
+// achievements of the kinds in failing, a given number of times each.
+type achievementServer struct {
+	mutex   sync.Mutex
+	failing map[string]int
+	posted  []string
+}
+
+func (s *achievementServer) ServeHTTP(writer http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/achievements/update-achievement" {
+		http.NotFound(writer, r)
+		return
+	}
+
+	var achievement Achievement
+	if err := json.NewDecoder(r.Body).Decode(&achievement); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.failing[achievement.Kind] > 0 {
+		s.failing[achievement.Kind]--
+		http.Error(writer, "try again", http.StatusServiceUnavailable)
+		return
+	}
+	s.posted = append(s.posted, achievement.Kind)
+}
+
+func (s *achievementServer) postedKinds() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Sorted(slices.Values(s.posted))
+}
+
+func newAchievementServer(t *testing.T, failing map[string]int) (*achievementServer, *HTTPBackend) {
+	server := &achievementServer{failing: failing}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	backend := NewHTTPBackend(config.BackendConfig{URL: httpServer.URL, Timeout: time.Second})
+	return server, backend
+}
+
+var testAchievements = []Achievement{
+	{UserID: "a", Kind: ACHIEVEMENT_FOOD_EATEN, Quantity: 3},
+	{UserID: "a", Kind: ACHIEVEMENT_MAX_SCORE, Quantity: 120},
+	{UserID: "a", Kind: ACHIEVEMENT_TIME_PLAYED, Quantity: 60},
+}
+
+func TestPostAchievementsOneByOne(t *testing.T) {
+	tests := []struct {
+		name       string
+		failing    map[string]int
+		wantPosted []string
+		wantFailed []string
+	}{
+		{"all posted", nil, []string{ACHIEVEMENT_FOOD_EATEN, ACHIEVEMENT_MAX_SCORE, ACHIEVEMENT_TIME_PLAYED}, nil},
+		{"goes on after a failure", map[string]int{ACHIEVEMENT_FOOD_EATEN: 1}, []string{ACHIEVEMENT_MAX_SCORE, ACHIEVEMENT_TIME_PLAYED}, []string{ACHIEVEMENT_FOOD_EATEN}},
+		{"all failed", map[string]int{ACHIEVEMENT_FOOD_EATEN: 1, ACHIEVEMENT_MAX_SCORE: 1, ACHIEVEMENT_TIME_PLAYED: 1}, nil, []string{ACHIEVEMENT_FOOD_EATEN, ACHIEVEMENT_MAX_SCORE, ACHIEVEMENT_TIME_PLAYED}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, backend := newAchievementServer(t, test.failing)
+
+			err := backend.PostAchievements(context.Background(), testAchievements)
+
+			if posted := server.postedKinds(); !slices.Equal(posted, test.wantPosted) {
+				t.Errorf("posted %v, want %v", posted, test.wantPosted)
+			}
+			var failed []string
+			var partial *AchievementsError
+			if errors.As(err, &partial) {
+				for _, achievement := range partial.Failed {
+					failed = append(failed, achievement.Kind)
+				}
+			} else if err != nil {
+				t.Fatalf("got error %v, want an AchievementsError", err)
+			}
+			if !slices.Equal(failed, test.wantFailed) {
+				t.Errorf("failed %v, want %v", failed, test.wantFailed)
+			}
+		})
+	}
+}
+
+// The outbox retries only the achievements that failed, so none is posted
+// twice.
+func TestOutboxRetriesFailedAchievements(t *testing.T) {
+	server, backend := newAchievementServer(t, map[string]int{ACHIEVEMENT_MAX_SCORE: 2})
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	outbox, err := NewOutbox(backend, config.BackendConfig{Outbox: config.OutboxConfig{MinBackoff: time.Second, MaxBackoff: time.Minute, MaxAttempts: 5}}, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { outbox.Close(context.Background()) })
+
+	outbox.PostAchievements(context.Background(), testAchievements)
+	advanceUntil(t, clock, time.Second, time.Minute, func() bool {
+		return outbox.Pending() == 0
+	})
+
+	want := []string{ACHIEVEMENT_FOOD_EATEN, ACHIEVEMENT_MAX_SCORE, ACHIEVEMENT_TIME_PLAYED}
+	if posted := server.postedKinds(); !slices.Equal(posted, want) {
+		t.Errorf("posted %v, want each of %v once", posted, want)
+	}
+}
@@ -0,0 +1,122 @@
+package galaxy
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryBackend keeps everything in memory, it is meant for tests and
+// forgets everything once the server stops.
+type MemoryBackend struct {
+	mutex sync.Mutex
+	state backendState
+}
+
+// backendState is everything a local backend stores.
+type backendState struct {
+	Games        map[uint32]*savedGame `json:"games"`
+	Achievements []Achievement         `json:"achievements"`
+	// Idempotency keys of the calls applied, so retries don't apply them
+	// twice.
+	PostedKeys map[string]bool `json:"postedKeys,omitempty"`
+}
+
+type savedGame struct {
+	Running bool         `json:"running"`
+	Players []PlayerData `json:"players"`
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		state: backendState{
+			Games:      make(map[uint32]*savedGame),
+			PostedKeys: make(map[string]bool),
+		},
+	}
+}
+
+func (m *MemoryBackend) game(gameID uint32) *savedGame {
+	game, exists := m.state.Games[gameID]
+	if !exists {
+		game = &savedGame{}
+		m.state.Games[gameID] = game
+	}
+	return game
+}
+
+// applied reports whether the call was already applied, and remembers it
+// otherwise. Calls without an idempotency key are always applied. The lock
+// must be held.
+func (m *MemoryBackend) applied(ctx context.Context) bool {
+	key := IdempotencyKey(ctx)
+	if key == "" {
+		return false
+	}
+	if m.state.PostedKeys[key] {
+		return true
+	}
+	m.state.PostedKeys[key] = true
+	return false
+}
+
+func (m *MemoryBackend) StartPrivateGame(ctx context.Context, gameID uint32) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.applied(ctx) {
+		m.game(gameID).Running = true
+	}
+	return nil
+}
+
+func (m *MemoryBackend) PausePrivateGame(ctx context.Context, gameID uint32) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.applied(ctx) {
+		m.game(gameID).Running = false
+	}
+	return nil
+}
+
+func (m *MemoryBackend) GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	game, exists := m.state.Games[gameID]
+	if !exists {
+		return nil, nil
+	}
+	return slices.Clone(game.Players), nil
+}
+
+func (m *MemoryBackend) UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.applied(ctx) {
+		m.game(gameID).Players = slices.Clone(players)
+	}
+	return nil
+}
+
+func (m *MemoryBackend) PostAchievements(ctx context.Context, achievements []Achievement) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.applied(ctx) {
+		m.state.Achievements = append(m.state.Achievements, achievements...)
+	}
+	return nil
+}
+
+// Running reports whether a private game was started and not paused.
+func (m *MemoryBackend) Running(gameID uint32) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	game, exists := m.state.Games[gameID]
+	return exists && game.Running
+}
+
+// Achievements returns every achievement posted, oldest first.
+func (m *MemoryBackend) Achievements() []Achievement {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return slices.Clone(m.state.Achievements)
+}
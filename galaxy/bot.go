@@ -1,17 +1,17 @@
 package galaxy
 
 import (
-	"log"
+	"log/slog"
 	"math"
-	"math/rand"
 	"time"
 
+	"galaxy.io/server/config"
 	"galaxy.io/server/proto"
 	"github.com/google/uuid"
 )
 
 // GenerateConstellationName returns a random name based on constellations
-func generateConstellationName() string {
+func generateConstellationName(rng *Random) string {
 	constellationNames := []string{
 		"Andromeda",
 		"Aquarius",
@@ -32,33 +32,38 @@ func generateConstellationName() string {
 		"Vela",
 	}
 
-	return constellationNames[rand.Intn(len(constellationNames))]
+	return constellationNames[rng.IntN(len(constellationNames))]
 }
 
 type Bot struct {
 	player *Player
 	target *Vector2D
 	steps	 uint32
+	clock  Clock
+	config config.BotConfig
 }
 
-func NewBot() *Bot {
-	player := NewPlayer(uuid.Nil, nil)
+func NewBot(cfg *config.Config, clock Clock, rng *Random) *Bot {
+	player := NewPlayer(uuid.Nil, nil, rng, cfg.World)
 
-	uuid, _ := uuid.NewRandom()
-	player.UpdatePlayerID(uuid)
-	player.UpdateRadius(50)
-	player.UpdateColor(randomColor())
-	player.UpdateUsername(generateConstellationName())
-	player.UpdatePosition(randomPosition())
+	player.UpdatePlayerID(rng.UUID())
+	player.UpdateRadius(cfg.World.StartingRadius)
+	player.UpdateColor(randomColor(rng))
+	player.UpdateUsername(generateConstellationName(rng))
 
-	log.Printf("creating new bot: %v", player.PlayerID)
+	slog.Info("creating new bot", "playerID", player.PlayerID, "username", player.Username)
 
 	return &Bot{
 		player: player,
+		clock:  clock,
+		config: cfg.Bots,
 	}
 }
 
 func (b *Bot) Start(w *World) {
+	botGoroutines.Inc()
+	defer botGoroutines.Dec()
+
 	for {
 		if b.player.disconnect {
 			return
@@ -74,7 +79,7 @@ func (b *Bot) Start(w *World) {
 			b.steps++
 		}
 
-		time.Sleep(60 * time.Millisecond)
+		b.clock.Sleep(60 * time.Millisecond)
 	}
 }
 
@@ -103,7 +108,7 @@ func (b *Bot) checkColision(w *World) {
 
 	w.playersMutex.RLock()
 	for _, player := range w.players {
-		if player.PlayerID == b.player.PlayerID || player.Radius + 5 > b.player.Radius {
+		if player.PlayerID == b.player.PlayerID || player.Radius + 5 > b.player.Radius || player.IsProtected() {
 			continue
 		}
 		if distance(b.player.Position, player.Position) < surface {
@@ -127,7 +132,7 @@ func (b *Bot) performPathfinding(w *World) {
 	w.foodMutex.RLock()
 	for _, food := range w.food {
 		dist := distance(b.player.Position, &food.position)
-		if dist < MAX_RANGE {
+		if dist < b.config.MaxRange {
 			foodTargets = append(foodTargets, &food.position)
 		}
 	}
@@ -139,7 +144,7 @@ func (b *Bot) performPathfinding(w *World) {
 			continue
 		}
 		dist := distance(b.player.Position, player.Position)
-		if dist < MAX_RANGE {
+		if dist < b.config.MaxRange {
 			playerTargets = append(playerTargets, player.Position)
 		}
 	}
@@ -163,7 +168,7 @@ func (b *Bot) performPathfinding(w *World) {
 
 	for _, target := range playerTargets {
 		dist := int32(distance(b.player.Position, target))
-		if (dist - PLAYER_PREFERANCE) < int32(distance(b.player.Position, bestTarget)) {
+		if (dist - b.config.PlayerPreference) < int32(distance(b.player.Position, bestTarget)) {
 			bestTarget = target
 		}
 	}
@@ -175,14 +180,15 @@ func (b *Bot) performPathfinding(w *World) {
 func (b *Bot) moveTowards(w *World, target *Vector2D) {
 	var newX uint32
 	var newY uint32
+	speed := b.config.Speed
 
 	deltaX := int32(b.player.Position.X) - int32(target.X)
 
-	if math.Abs(float64(deltaX)) > SPEED {
+	if math.Abs(float64(deltaX)) > float64(speed) {
 		if deltaX < 0 {
-			newX = b.player.Position.X + SPEED
+			newX = b.player.Position.X + speed
 		} else {
-			newX = b.player.Position.X - SPEED
+			newX = b.player.Position.X - speed
 		}
 	} else {
 		newX = target.X
@@ -190,11 +196,11 @@ func (b *Bot) moveTowards(w *World, target *Vector2D) {
 
 	deltaY := int32(b.player.Position.Y) - int32(target.Y)
 
-	if math.Abs(float64(deltaY)) > SPEED {
+	if math.Abs(float64(deltaY)) > float64(speed) {
 		if deltaY < 0 {
-			newY = b.player.Position.Y + SPEED
+			newY = b.player.Position.Y + speed
 		} else {
-			newY = b.player.Position.Y - SPEED
+			newY = b.player.Position.Y - speed
 		}
 	} else {
 		newY = target.Y
@@ -222,10 +228,3 @@ func distance(v1 *Vector2D, v2 *Vector2D) uint32 {
 	dist := math.Abs(dx) + math.Abs(dy)
 	return uint32(dist)
 }
-
-const (
-	MAX_RANGE = 1100
-	SPEED     = 10
-	PLAYER_PREFERANCE = 500
-	// FOOD_SURFACE = math.Pi*30*30
-)
@@ -0,0 +1,181 @@
+package galaxy
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrorChatDisabled    = errors.New("chat is disabled in this world")
+	ErrorChatNotJoined   = errors.New("join before chatting")
+	ErrorChatMuted       = errors.New("you are muted")
+	ErrorChatEmpty       = errors.New("empty message")
+	ErrorChatTooLong     = errors.New("message too long")
+	ErrorChatRateLimited = errors.New("sending messages too fast")
+)
+
+// Chat enforces the length, rate limit and word filter of chat messages.
+type Chat struct {
+	config config.ChatConfig
+	// nil when no words are filtered
+	filter *regexp.Regexp
+
+	mutex sync.Mutex
+	// When the recent messages of every player were sent, by playerID.
+	sent map[uuid.UUID][]time.Time
+}
+
+func NewChat(cfg config.ChatConfig) *Chat {
+	chat := &Chat{
+		config: cfg,
+		sent:   make(map[uuid.UUID][]time.Time),
+	}
+
+	if len(cfg.Filter) > 0 {
+		words := make([]string, len(cfg.Filter))
+		for i, word := range cfg.Filter {
+			words[i] = regexp.QuoteMeta(word)
+		}
+		chat.filter = regexp.MustCompile(`(?i)\b(` + strings.Join(words, "|") + `)\b`)
+	}
+
+	return chat
+}
+
+// check validates a message sent at now and returns it filtered.
+func (c *Chat) check(playerID uuid.UUID, message string, now time.Time) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", ErrorChatEmpty
+	}
+	if utf8.RuneCountInString(message) > c.config.MaxLength {
+		return "", ErrorChatTooLong
+	}
+	if !c.allow(playerID, now) {
+		return "", ErrorChatRateLimited
+	}
+	return c.filtered(message), nil
+}
+
+// allow counts a message towards the rate limit of the player, reporting
+// whether it is within the limit.
+func (c *Chat) allow(playerID uuid.UUID, now time.Time) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	recent := c.sent[playerID][:0]
+	for _, sentAt := range c.sent[playerID] {
+		if now.Sub(sentAt) < c.config.RatePeriod {
+			recent = append(recent, sentAt)
+		}
+	}
+
+	allowed := len(recent) < c.config.RateLimit
+	if allowed {
+		recent = append(recent, now)
+	}
+	c.sent[playerID] = recent
+	return allowed
+}
+
+func (c *Chat) filtered(message string) string {
+	if c.filter == nil {
+		return message
+	}
+	return c.filter.ReplaceAllStringFunc(message, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+}
+
+// forget drops the rate limit history of a player that left.
+func (c *Chat) forget(playerID uuid.UUID) {
+	c.mutex.Lock()
+	delete(c.sent, playerID)
+	c.mutex.Unlock()
+}
+
+func (w *World) operationChat(player *Player, operation *pb.ChatOperation) {
+	logger := w.operationLogger(player, pb.OperationType_OpChat)
+	if operation == nil {
+		logger.Warn("nil operation in chat")
+		return
+	}
+
+	message, err := w.checkChat(player, operation.GetMessage())
+	if err != nil {
+		logger.Debug("chat message rejected", "err", err)
+		w.rejectChat(player, operation, err)
+		return
+	}
+
+	event := &pb.Event{
+		EventType: pb.EventType_EvChat.Enum(),
+		EventData: &pb.Event_ChatEvent{
+			ChatEvent: &pb.ChatEvent{
+				PlayerID: player.PlayerID[:],
+				Username: proto.String(player.Username),
+				Message:  &message,
+				Channel:  operation.GetChannel().Enum(),
+				GameID:   w.gameID,
+			},
+		},
+	}
+
+	switch operation.GetChannel() {
+	case pb.ChatChannel_ChatDirect:
+		to, err := uuid.FromBytes(operation.GetTo())
+		if err != nil {
+			w.rejectChat(player, operation, err)
+			return
+		}
+		receiver, exists := w.connectedPlayer(to)
+		if !exists {
+			w.rejectChat(player, operation, ErrorPlayerNotFound)
+			return
+		}
+		w.sendEvent(receiver, event)
+		if receiver != player {
+			w.sendEvent(player, event)
+		}
+	default:
+		w.broadcastEvent(event)
+	}
+}
+
+func (w *World) checkChat(player *Player, message string) (string, error) {
+	if w.chat == nil {
+		return "", ErrorChatDisabled
+	}
+	if player.PlayerID == uuid.Nil {
+		return "", ErrorChatNotJoined
+	}
+	if w.isMuted(player.PlayerID) {
+		return "", ErrorChatMuted
+	}
+	return w.chat.check(player.PlayerID, message, w.clock.Now())
+}
+
+// rejectChat tells the sender its message wasn't delivered and why.
+func (w *World) rejectChat(player *Player, operation *pb.ChatOperation, reason error) {
+	w.sendEvent(player, &pb.Event{
+		EventType: pb.EventType_EvChat.Enum(),
+		EventData: &pb.Event_ChatEvent{
+			ChatEvent: &pb.ChatEvent{
+				PlayerID: player.PlayerID[:],
+				Username: proto.String(player.Username),
+				Message:  proto.String(operation.GetMessage()),
+				Channel:  operation.GetChannel().Enum(),
+				Rejected: proto.String(reason.Error()),
+			},
+		},
+	})
+}
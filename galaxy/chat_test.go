@@ -0,0 +1,88 @@
+package galaxy
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	"github.com/google/uuid"
+)
+
+func newTestChat() *Chat {
+	return NewChat(config.ChatConfig{
+		MaxLength:  20,
+		RateLimit:  3,
+		RatePeriod: 10 * time.Second,
+		Filter:     []string{"darn", "heck"},
+	})
+}
+
+func TestChatCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+		wantErr error
+	}{
+		{"passes clean messages", "hello there", "hello there", nil},
+		{"trims spaces", "  hi  ", "hi", nil},
+		{"rejects empty messages", "   ", "", ErrorChatEmpty},
+		{"rejects long messages", "this message is far too long", "", ErrorChatTooLong},
+		{"counts characters, not bytes", "ñññññññññññññññññññ", "ñññññññññññññññññññ", nil},
+		{"hides filtered words", "darn it", "**** it", nil},
+		{"ignores the case", "HECK no", "**** no", nil},
+		{"only whole words", "darned hecks", "darned hecks", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newTestChat().check(uuid.New(), test.message, time.Now())
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestChatRateLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		// When every message is sent, from the first one.
+		sent   []time.Duration
+		forget bool
+		want   []bool
+	}{
+		{"up to the limit", []time.Duration{0, time.Second, 2 * time.Second}, false, []bool{true, true, true}},
+		{"rejected over the limit", []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second}, false, []bool{true, true, true, false}},
+		{"allowed again once the period passed", []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 10 * time.Second}, false, []bool{true, true, true, false, true}},
+		{"rejected messages don't count", []time.Duration{0, 0, 0, 5 * time.Second, 10 * time.Second, 10 * time.Second}, false, []bool{true, true, true, false, true, true}},
+		{"forgotten when leaving", []time.Duration{0, 0, 0, time.Second}, true, []bool{true, true, true, true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chat := newTestChat()
+			playerID := uuid.New()
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			var got []bool
+			for i, sent := range test.sent {
+				if test.forget && i == len(test.sent)-1 {
+					chat.forget(playerID)
+				}
+				got = append(got, chat.allow(playerID, start.Add(sent)))
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got allowed %v, want %v", got, test.want)
+			}
+			if !chat.allow(uuid.New(), start) {
+				t.Errorf("another player was rate limited")
+			}
+		})
+	}
+}
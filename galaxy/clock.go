@@ -0,0 +1,247 @@
+package galaxy
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// MANUAL_CLOCK_SETTLE is how long Advance waits for a woken goroutine that
+// doesn't go back to sleep before waking the next one.
+const MANUAL_CLOCK_SETTLE = 10 * time.Millisecond
+
+// MANUAL_CLOCK_GRACE is how long Advance keeps waiting for more goroutines
+// to sleep once one did.
+const MANUAL_CLOCK_GRACE = time.Millisecond
+
+// Clock abstracts the passage of time for the world and its bots, so they
+// can be driven by a ManualClock instead of the wall clock.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	// After sends the time on the returned channel once d has passed.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer is After for callers that may stop waiting before d has
+	// passed, and have to release the timer when they do.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer sends the time on C once, unless it is stopped first.
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, it reports whether it did.
+	Stop() bool
+}
+
+type realClock struct{}
+
+// NewRealClock returns a Clock backed by the time package.
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+type sleeper struct {
+	until time.Time
+	// Called by Advance once the deadline is reached.
+	wake func()
+}
+
+// ManualClock is a Clock that only moves forward when Advance is called.
+// Goroutines calling Sleep block until the clock has been advanced past
+// their deadline, which makes timing dependent code deterministic.
+type ManualClock struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	now      time.Time
+	sleepers []*sleeper
+	// Signalled every time a goroutine starts sleeping.
+	asleep chan struct{}
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	c := &ManualClock{
+		now:    start,
+		asleep: make(chan struct{}, 1),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	c.add(d, func() { close(done) })
+	<-done
+}
+
+// After sends the time once the clock has been advanced past d. Until
+// then it counts as a sleeper.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer returns a timer that counts as a sleeper until the clock has
+// been advanced past d or it is stopped.
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	t := &manualTimer{clock: c, ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.Now()
+		return t
+	}
+	t.sleeper = c.add(d, func() { t.ch <- c.Now() })
+	return t
+}
+
+// add registers a sleeper woken once the clock has been advanced past d.
+func (c *ManualClock) add(d time.Duration, wake func()) *sleeper {
+	c.mu.Lock()
+	s := &sleeper{
+		until: c.now.Add(d),
+		wake:  wake,
+	}
+	c.sleepers = append(c.sleepers, s)
+	c.cond.Broadcast()
+	c.mu.Unlock()
+
+	select {
+	case c.asleep <- struct{}{}:
+	default:
+	}
+	return s
+}
+
+// remove unregisters a sleeper that hasn't been woken, it reports whether
+// there was one.
+func (c *ManualClock) remove(s *sleeper) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	before := len(c.sleepers)
+	c.sleepers = slices.DeleteFunc(c.sleepers, func(other *sleeper) bool { return other == s })
+	return len(c.sleepers) < before
+}
+
+type manualTimer struct {
+	clock *ManualClock
+	// Nil when the timer fired on creation.
+	sleeper *sleeper
+	ch      chan time.Time
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *manualTimer) Stop() bool {
+	return t.sleeper != nil && t.clock.remove(t.sleeper)
+}
+
+// Advance moves the clock forward, waking the sleepers whose deadline is
+// reached one at a time, earliest first. Each one sees the clock at its own
+// deadline, and the next one is only woken once it went back to sleep, with
+// the goroutines it started, or MANUAL_CLOCK_SETTLE passed, so sleepers that wake again before the end
+// of the advance are woken in order too.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		next := c.earliest()
+		if next == nil || next.until.After(end) {
+			c.now = end
+			c.mu.Unlock()
+			return
+		}
+		c.sleepers = slices.DeleteFunc(c.sleepers, func(s *sleeper) bool { return s == next })
+		c.now = next.until
+		c.mu.Unlock()
+
+		select {
+		case <-c.asleep:
+		default:
+		}
+		next.wake()
+		c.settle()
+	}
+}
+
+// settle waits for a woken sleeper to go back to sleep, along with the
+// goroutines it started, giving up after MANUAL_CLOCK_SETTLE. Once one of
+// them sleeps the others get MANUAL_CLOCK_GRACE to follow.
+func (c *ManualClock) settle() {
+	wait := MANUAL_CLOCK_SETTLE
+	for {
+		select {
+		case <-c.asleep:
+			wait = MANUAL_CLOCK_GRACE
+		case <-time.After(wait):
+			return
+		}
+	}
+}
+
+// earliest returns the sleeper with the closest deadline, the first one to
+// sleep among equals. The lock must be held.
+func (c *ManualClock) earliest() *sleeper {
+	var earliest *sleeper
+	for _, s := range c.sleepers {
+		if earliest == nil || s.until.Before(earliest.until) {
+			earliest = s
+		}
+	}
+	return earliest
+}
+
+// Sleepers returns the number of goroutines currently blocked in Sleep.
+func (c *ManualClock) Sleepers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.sleepers)
+}
+
+// BlockUntil waits until at least n goroutines are blocked in Sleep.
+func (c *ManualClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.sleepers) < n {
+		c.cond.Wait()
+	}
+}
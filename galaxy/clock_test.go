@@ -0,0 +1,134 @@
+package galaxy
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestManualClockAdvance(t *testing.T) {
+	tests := []struct {
+		name    string
+		sleeps  []time.Duration
+		advance time.Duration
+		// Index of the sleepers woken, in order.
+		want []int
+	}{
+		{"earliest first", []time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond}, 30 * time.Millisecond, []int{1, 2, 0}},
+		{"only the due ones", []time.Duration{10 * time.Millisecond, 50 * time.Millisecond}, 20 * time.Millisecond, []int{0}},
+		{"nothing due", []time.Duration{time.Second}, 999 * time.Millisecond, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			clock := NewManualClock(start)
+
+			var mutex sync.Mutex
+			var woken []int
+			for i, sleep := range test.sleeps {
+				go func() {
+					clock.Sleep(sleep)
+					mutex.Lock()
+					woken = append(woken, i)
+					mutex.Unlock()
+					if now := clock.Now(); !now.Equal(start.Add(sleep)) {
+						t.Errorf("sleeper %d woke at %v, want its deadline %v", i, now.Sub(start), sleep)
+					}
+				}()
+				clock.BlockUntil(i + 1)
+			}
+			clock.Advance(test.advance)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !slices.Equal(woken, test.want) {
+				t.Errorf("woke %v, want %v", woken, test.want)
+			}
+			if got := clock.Sleepers(); got != len(test.sleeps)-len(test.want) {
+				t.Errorf("got %d sleepers left, want %d", got, len(test.sleeps)-len(test.want))
+			}
+		})
+	}
+}
+
+// A sleeper that goes back to sleep before the end of an advance is woken
+// again in the same advance, after the sleepers due before it.
+func TestManualClockAdvanceSleepAgain(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var mutex sync.Mutex
+	var woken []string
+	wake := func(name string) {
+		mutex.Lock()
+		woken = append(woken, name)
+		mutex.Unlock()
+	}
+
+	go func() {
+		for range 3 {
+			clock.Sleep(10 * time.Millisecond)
+			wake("ticker")
+		}
+	}()
+	clock.BlockUntil(1)
+	go func() {
+		clock.Sleep(25 * time.Millisecond)
+		wake("sleeper")
+	}()
+	clock.BlockUntil(2)
+
+	clock.Advance(30 * time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	want := []string{"ticker", "ticker", "sleeper", "ticker"}
+	if !slices.Equal(woken, want) {
+		t.Errorf("woke %v, want %v", woken, want)
+	}
+}
+
+func TestManualClockTimer(t *testing.T) {
+	tests := []struct {
+		name    string
+		stopAt  time.Duration
+		advance time.Duration
+		// Whether Stop prevented the timer from firing.
+		wantStopped bool
+		wantFired   bool
+	}{
+		{"fires at its deadline", 20 * time.Millisecond, 10 * time.Millisecond, false, true},
+		{"stopped before its deadline", 5 * time.Millisecond, 10 * time.Millisecond, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			clock := NewManualClock(start)
+			timer := clock.NewTimer(10 * time.Millisecond)
+
+			clock.Advance(min(test.stopAt, test.advance))
+			if stopped := timer.Stop(); stopped != test.wantStopped {
+				t.Errorf("got stopped %v, want %v", stopped, test.wantStopped)
+			}
+			clock.Advance(test.advance)
+
+			select {
+			case now := <-timer.C():
+				if !test.wantFired {
+					t.Errorf("fired at %v, want it stopped", now.Sub(start))
+				} else if !now.Equal(start.Add(10 * time.Millisecond)) {
+					t.Errorf("fired at %v, want its deadline", now.Sub(start))
+				}
+			default:
+				if test.wantFired {
+					t.Errorf("didn't fire")
+				}
+			}
+			if sleepers := clock.Sleepers(); sleepers != 0 {
+				t.Errorf("got %d sleepers left, want 0", sleepers)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ type ClientConnection interface {
 	SendEvent(event *pb.Event) error
 
 	Close()
+	// Done is closed once the connection is closed, by either side.
+	Done() <-chan struct{}
 }
 
 
@@ -0,0 +1,160 @@
+package galaxy
+
+import (
+	"sync"
+	"time"
+
+	pb "galaxy.io/server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// delayedEvent is an event held back until at.
+type delayedEvent struct {
+	at    time.Time
+	event *pb.Event
+}
+
+// DelayedFeed holds back the events sent to a spectator and delivers them
+// in order once the delay has passed, so it can't tell players where the
+// others are right now.
+type DelayedFeed struct {
+	delay time.Duration
+	clock Clock
+
+	mutex   sync.Mutex
+	pending []delayedEvent
+	stopped bool
+	// Held while an event is sent, so Stop can't return before it is out.
+	sending sync.Mutex
+	// Signalled when an event is pushed, stop is closed by Stop.
+	wake chan struct{}
+	stop chan struct{}
+}
+
+func NewDelayedFeed(delay time.Duration, clock Clock) *DelayedFeed {
+	return &DelayedFeed{
+		delay: delay,
+		clock: clock,
+		wake:  make(chan struct{}, 1),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Push holds back a copy of the event, the original may point to fields
+// of players that change before it is delivered.
+func (f *DelayedFeed) Push(event *pb.Event) {
+	f.mutex.Lock()
+	if f.stopped {
+		f.mutex.Unlock()
+		return
+	}
+	f.pending = append(f.pending, delayedEvent{
+		at:    f.clock.Now().Add(f.delay),
+		event: proto.Clone(event).(*pb.Event),
+	})
+	f.mutex.Unlock()
+
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers the events through send as they come due, until the feed
+// is stopped or a send fails.
+func (f *DelayedFeed) Run(send func(*pb.Event) error) error {
+	for {
+		f.mutex.Lock()
+		if len(f.pending) == 0 {
+			f.mutex.Unlock()
+			select {
+			case <-f.wake:
+				continue
+			case <-f.stop:
+				return nil
+			}
+		}
+		next := f.pending[0]
+		f.mutex.Unlock()
+
+		if wait := next.at.Sub(f.clock.Now()); wait > 0 {
+			select {
+			case <-f.clock.After(wait):
+			case <-f.stop:
+				return nil
+			}
+		}
+
+		f.sending.Lock()
+		f.mutex.Lock()
+		if f.stopped {
+			f.mutex.Unlock()
+			f.sending.Unlock()
+			return nil
+		}
+		f.pending[0] = delayedEvent{}
+		f.pending = f.pending[1:]
+		f.mutex.Unlock()
+
+		err := send(next.event)
+		f.sending.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Stop ends Run and returns the events still held back, in order, once
+// the event being sent is out.
+func (f *DelayedFeed) Stop() []*pb.Event {
+	f.sending.Lock()
+	defer f.sending.Unlock()
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.stopped {
+		return nil
+	}
+	f.stopped = true
+	events := make([]*pb.Event, len(f.pending))
+	for i, pending := range f.pending {
+		events[i] = pending.event
+	}
+	f.pending = nil
+	close(f.stop)
+	return events
+}
+
+// delaySpectator starts holding back the events sent to a spectator, when
+// this kind of world delays its spectators.
+func (w *World) delaySpectator(spectator *Player) {
+	if w.spectatorDelay == 0 {
+		return
+	}
+	feed := NewDelayedFeed(w.spectatorDelay, w.clock)
+	if !spectator.feed.CompareAndSwap(nil, feed) {
+		return
+	}
+
+	go func() {
+		if err := feed.Run(spectator.SendEvent); err != nil {
+			w.playerLogger(spectator).Info("removing spectator after failing to send a delayed event", "err", err)
+			w.removePlayer(spectator)
+		}
+	}()
+}
+
+// undelay sends the events still held back right away, and the following
+// ones as they come. Holding the players lock keeps broadcasts from going
+// out before the held back events.
+func (w *World) undelay(player *Player) {
+	feed := player.feed.Swap(nil)
+	if feed == nil {
+		return
+	}
+	for _, event := range feed.Stop() {
+		if err := player.SendEvent(event); err != nil {
+			w.playerLogger(player).Debug("unable to send a delayed event", "err", err)
+			return
+		}
+	}
+}
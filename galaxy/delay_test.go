@@ -0,0 +1,119 @@
+package galaxy
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+// eventTypes returns the type of every event.
+func eventTypes(events []*pb.Event) []pb.EventType {
+	var types []pb.EventType
+	for _, event := range events {
+		types = append(types, event.GetEventType())
+	}
+	return types
+}
+
+func TestDelayedFeed(t *testing.T) {
+	const delay = 30 * time.Second
+	pushed := []pb.EventType{pb.EventType_EvPause, pb.EventType_EvFeed, pb.EventType_EvChat}
+	tests := []struct {
+		name string
+		// When every event is pushed, from the start.
+		pushes []time.Duration
+		// How long after the start the feed is stopped.
+		stopAt      time.Duration
+		wantSent    []pb.EventType
+		wantStopped []pb.EventType
+	}{
+		{"nothing before the delay", []time.Duration{0, time.Second}, delay - time.Second, nil, pushed[:2]},
+		{"in order after the delay", []time.Duration{0, time.Second, 2 * time.Second}, delay + 2*time.Second, pushed, nil},
+		{"only the due ones", []time.Duration{0, 10 * time.Second, 20 * time.Second}, delay + 10*time.Second, pushed[:2], pushed[2:]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			feed := NewDelayedFeed(delay, clock)
+			conn := newTestConnection()
+			stopped := run(func() { feed.Run(conn.SendEvent) })
+
+			var elapsed time.Duration
+			for i, at := range test.pushes {
+				clock.Advance(at - elapsed)
+				elapsed = at
+				feed.Push(&pb.Event{EventType: pushed[i].Enum()})
+			}
+			advanceUntil(t, clock, time.Second, test.stopAt-elapsed, func() bool {
+				return len(conn.sent()) == len(test.wantSent)
+			})
+
+			held := feed.Stop()
+			advanceUntil(t, clock, time.Second, time.Second, stopped)
+			if sent := eventTypes(conn.sent()); !slices.Equal(sent, test.wantSent) {
+				t.Errorf("sent %v, want %v", sent, test.wantSent)
+			}
+			if held := eventTypes(held); !slices.Equal(held, test.wantStopped) {
+				t.Errorf("held back %v, want %v", held, test.wantStopped)
+			}
+		})
+	}
+}
+
+// Eaten players watch the world late like any spectator, until they
+// respawn and get what was held back right away.
+func TestEatenSpectatorDelay(t *testing.T) {
+	w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.Spectators.Public = true
+		cfg.Spectators.Delay = 30 * time.Second
+		cfg.World.MinPlayers = 0
+	})
+	player, conn := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+
+	w.killPlayer(player)
+	w.broadcastEvent(&pb.Event{EventType: pb.EventType_EvPause.Enum()})
+	clock.Advance(10 * time.Second)
+	if got := len(conn.received(pb.EventType_EvPause)); got != 0 {
+		t.Fatalf("eaten player got %d events before the delay, want 0", got)
+	}
+
+	respawned := run(func() { w.operationRespawn(player) })
+	advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, respawned)
+	if got := len(conn.received(pb.EventType_EvPause)); got != 1 {
+		t.Errorf("respawned player got %d held back events, want 1", got)
+	}
+	w.broadcastEvent(&pb.Event{EventType: pb.EventType_EvPause.Enum()})
+	if got := len(conn.received(pb.EventType_EvPause)); got != 2 {
+		t.Errorf("respawned player got %d events, want 2 without a delay", got)
+	}
+}
+
+func TestSpectatorsMax(t *testing.T) {
+	w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.Spectators.Max = 2
+	})
+	// eaten players don't count
+	eaten, _ := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+	w.killPlayer(eaten)
+
+	var conns []*testConnection
+	for range 3 {
+		conn := newTestConnection()
+		spectator := NewPlayer(uuid.New(), conn, w.rng, w.config.World)
+		w.registerPlayer(spectator)
+		spectating := run(func() { w.operationSpectate(spectator, &pb.SpectateOperation{}) })
+		advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, spectating)
+		conns = append(conns, conn)
+	}
+
+	for i, conn := range conns {
+		if want := i == 2; conn.isClosed() != want {
+			t.Errorf("spectator %d got disconnected %v, want %v", i, conn.isClosed(), want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package galaxy
+
+import (
+	"sync"
+	"time"
+
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DIRECTOR_EAT_DISTANCE is how close the edge of a player has to be to
+	// a smaller one for the eat to be imminent.
+	DIRECTOR_EAT_DISTANCE = 200
+	// DIRECTOR_LEADER_WEIGHT lowers the interest of the leader, which is
+	// shown when nothing else is going on.
+	DIRECTOR_LEADER_WEIGHT = 0.5
+)
+
+// shot is something the camera can look at.
+type shot struct {
+	target uuid.UUID
+	other  uuid.UUID
+	reason pb.CameraReason
+	// How interesting it is, bigger is better.
+	score float64
+}
+
+// Director moves the camera of the spectators that asked for it, staying
+// on a player for a while before switching to something more interesting.
+type Director struct {
+	mutex   sync.Mutex
+	current shot
+	since   time.Time
+}
+
+// runDirector looks for something interesting once every interval, until
+// the world shuts down.
+func (w *World) runDirector() {
+	for !w.shuttingDown.Load() {
+		w.clock.Sleep(w.config.Director.Interval)
+		if len(w.directedSpectators()) == 0 {
+			continue
+		}
+		if changed, current := w.pickShot(); changed {
+			w.sendCameraTarget(w.directedSpectators(), current)
+		}
+	}
+}
+
+// pickShot updates the shot of the director, reporting whether it changed.
+func (w *World) pickShot() (bool, shot) {
+	shots := w.shots()
+	now := w.clock.Now()
+
+	w.director.mutex.Lock()
+	defer w.director.mutex.Unlock()
+	current := w.director.current
+
+	// what the current target is doing now
+	var refreshed shot
+	for _, s := range shots {
+		if s.target == current.target && s.score > refreshed.score {
+			refreshed = s
+		}
+	}
+
+	var best shot
+	for _, s := range shots {
+		if s.score > best.score {
+			best = s
+		}
+	}
+
+	gone := current.target == uuid.Nil || !w.isPlaying(current.target)
+	next := refreshed
+	if next.target == uuid.Nil && !gone {
+		// still around, but not doing anything interesting
+		next = shot{target: current.target, reason: current.reason}
+	}
+
+	switch {
+	case gone:
+		next = best
+	case now.Sub(w.director.since) < w.config.Director.MinHold:
+	case best.target != current.target && best.score > next.score*w.config.Director.SwitchMargin:
+		next = best
+	}
+
+	if next.target != current.target {
+		w.director.since = now
+	}
+	w.director.current = next
+	return next.target != current.target || next.other != current.other || next.reason != current.reason, next
+}
+
+// shots returns everything worth looking at, judged like the bots do in
+// performPathfinding: by how close players are to smaller ones.
+func (w *World) shots() []shot {
+	var shots []shot
+	if leader := leaderOf(w.standings()); leader != nil {
+		leader.RLock()
+		shots = append(shots, shot{
+			target: leader.PlayerID,
+			reason: pb.CameraReason_CameraLeader,
+			score:  DIRECTOR_LEADER_WEIGHT * float64(leader.Radius),
+		})
+		leader.RUnlock()
+	}
+
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+
+	for _, hunter := range w.players {
+		for _, prey := range w.players {
+			if prey == hunter || prey.Radius+5 > hunter.Radius {
+				continue
+			}
+			// the bigger both players are and the closer they get, the
+			// more interesting, an imminent eat always beats a chase
+			size := float64(hunter.Radius + prey.Radius)
+			gap := float64(distance(hunter.GetPosition(), prey.GetPosition())) - float64(hunter.Radius)
+			switch {
+			case gap < DIRECTOR_EAT_DISTANCE:
+				shots = append(shots, shot{
+					target: hunter.PlayerID,
+					other:  prey.PlayerID,
+					reason: pb.CameraReason_CameraEat,
+					score:  size * (2 - max(gap, 0)/DIRECTOR_EAT_DISTANCE),
+				})
+			case gap < float64(w.config.Bots.MaxRange):
+				shots = append(shots, shot{
+					target: hunter.PlayerID,
+					other:  prey.PlayerID,
+					reason: pb.CameraReason_CameraChase,
+					score:  size * (1 - gap/float64(w.config.Bots.MaxRange)),
+				})
+			}
+		}
+	}
+	return shots
+}
+
+// directedSpectators returns the spectators whose camera is moved by the
+// server.
+func (w *World) directedSpectators() []*Player {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+
+	var directed []*Player
+	for _, spectator := range w.spectators {
+		spectator.RLock()
+		if spectator.directed {
+			directed = append(directed, spectator)
+		}
+		spectator.RUnlock()
+	}
+	return directed
+}
+
+// direct hands the camera of a spectator to the director and sends it the
+// current shot.
+func (w *World) direct(spectator *Player) {
+	spectator.Lock()
+	spectator.following = uuid.Nil
+	spectator.directed = true
+	spectator.Unlock()
+
+	w.sendEvent(spectator, &pb.Event{
+		EventType: pb.EventType_EvSpectate.Enum(),
+		EventData: &pb.Event_SpectateEvent{
+			SpectateEvent: &pb.SpectateEvent{
+				Director: proto.Bool(true),
+			},
+		},
+	})
+
+	w.director.mutex.Lock()
+	current := w.director.current
+	w.director.mutex.Unlock()
+	w.sendCameraTarget([]*Player{spectator}, current)
+}
+
+func (w *World) sendCameraTarget(receivers []*Player, s shot) {
+	target := &pb.CameraTargetEvent{
+		Reason: s.reason.Enum(),
+	}
+	if s.target != uuid.Nil {
+		target.PlayerID = s.target[:]
+	}
+	if s.other != uuid.Nil {
+		target.OtherPlayerID = s.other[:]
+	}
+
+	event := &pb.Event{
+		EventType: pb.EventType_EvCameraTarget.Enum(),
+		EventData: &pb.Event_CameraTargetEvent{
+			CameraTargetEvent: target,
+		},
+	}
+	for _, receiver := range receivers {
+		w.sendEvent(receiver, event)
+	}
+}
@@ -0,0 +1,123 @@
+package galaxy
+
+import (
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+func TestShots(t *testing.T) {
+	type testPlayer struct {
+		position Vector2D
+		radius   uint32
+	}
+	tests := []struct {
+		name    string
+		players []testPlayer
+		// Index of the players in the best shot, -1 for none.
+		wantTarget int
+		wantOther  int
+		wantReason pb.CameraReason
+	}{
+		{"nobody", nil, -1, -1, pb.CameraReason_CameraLeader},
+		{"the leader when nothing goes on", []testPlayer{{Vector2D{X: 1000, Y: 1000}, 100}, {Vector2D{X: 8000, Y: 8000}, 200}}, 1, -1, pb.CameraReason_CameraLeader},
+		{"a chase", []testPlayer{{Vector2D{X: 1000, Y: 1000}, 200}, {Vector2D{X: 1500, Y: 1000}, 50}}, 0, 1, pb.CameraReason_CameraChase},
+		{"an eat over a chase", []testPlayer{
+			{Vector2D{X: 1000, Y: 1000}, 100}, {Vector2D{X: 1150, Y: 1000}, 50},
+			{Vector2D{X: 5000, Y: 5000}, 200}, {Vector2D{X: 5700, Y: 5000}, 50},
+		}, 0, 1, pb.CameraReason_CameraEat},
+		{"the bigger eat", []testPlayer{
+			{Vector2D{X: 1000, Y: 1000}, 100}, {Vector2D{X: 1150, Y: 1000}, 50},
+			{Vector2D{X: 5000, Y: 5000}, 300}, {Vector2D{X: 5350, Y: 5000}, 50},
+		}, 2, 3, pb.CameraReason_CameraEat},
+		{"no prey of a similar size", []testPlayer{{Vector2D{X: 1000, Y: 1000}, 100}, {Vector2D{X: 1100, Y: 1000}, 97}}, 0, -1, pb.CameraReason_CameraLeader},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, _, _ := newTestWorld(t, nil)
+			players := make([]*Player, len(test.players))
+			for i, player := range test.players {
+				players[i], _ = addTestPlayer(w, player.position, player.radius)
+			}
+			id := func(i int) uuid.UUID {
+				if i < 0 {
+					return uuid.Nil
+				}
+				return players[i].PlayerID
+			}
+
+			var best shot
+			for _, s := range w.shots() {
+				if s.score > best.score {
+					best = s
+				}
+			}
+			if best.target != id(test.wantTarget) || best.other != id(test.wantOther) {
+				t.Errorf("got shot of %v and %v, want %v and %v", best.target, best.other, id(test.wantTarget), id(test.wantOther))
+			}
+			if test.wantTarget >= 0 && best.reason != test.wantReason {
+				t.Errorf("got reason %v, want %v", best.reason, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestPickShot(t *testing.T) {
+	w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.Director.MinHold = 4 * time.Second
+		cfg.Director.SwitchMargin = 1.5
+	})
+	leader, _ := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 300)
+	hunter, _ := addTestPlayer(w, Vector2D{X: 5000, Y: 5000}, 250)
+	prey, _ := addTestPlayer(w, Vector2D{X: 9000, Y: 9000}, 50)
+
+	steps := []struct {
+		name        string
+		change      func()
+		advance     time.Duration
+		wantChanged bool
+		wantTarget  *Player
+		wantReason  pb.CameraReason
+	}{
+		{"starts on the leader", nil, 0, true, leader, pb.CameraReason_CameraLeader},
+		{"holds it for a while", func() {
+			prey.UpdatePosition(&Vector2D{X: 5300, Y: 5000})
+		}, time.Second, false, leader, pb.CameraReason_CameraLeader},
+		{"switches to a much better shot", nil, 3 * time.Second, true, hunter, pb.CameraReason_CameraEat},
+		{"stays on its target after the eat", func() {
+			prey.UpdatePosition(&Vector2D{X: 9000, Y: 9000})
+		}, time.Second, true, hunter, pb.CameraReason_CameraEat},
+		{"moves on when its target leaves", func() {
+			w.playersMutex.Lock()
+			delete(w.players, hunter.PlayerID)
+			w.playersMutex.Unlock()
+		}, 0, true, leader, pb.CameraReason_CameraLeader},
+		{"keeps a shot not better by the margin", func() {
+			hunter.UpdateRadius(280)
+			hunter.UpdatePosition(&Vector2D{X: 5000, Y: 5000})
+			prey.UpdatePosition(&Vector2D{X: 5000, Y: 5680})
+			w.playersMutex.Lock()
+			w.players[hunter.PlayerID] = hunter
+			w.playersMutex.Unlock()
+		}, 5 * time.Second, false, leader, pb.CameraReason_CameraLeader},
+	}
+
+	for _, step := range steps {
+		if step.change != nil {
+			step.change()
+		}
+		clock.Advance(step.advance)
+
+		changed, current := w.pickShot()
+		if changed != step.wantChanged {
+			t.Errorf("%s: got changed %v, want %v", step.name, changed, step.wantChanged)
+		}
+		if current.target != step.wantTarget.PlayerID || current.reason != step.wantReason {
+			t.Errorf("%s: got %v for %v, want %v for %v", step.name, current.target, current.reason, step.wantTarget.PlayerID, step.wantReason)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package galaxy
+
+import (
+	"strconv"
+	"strings"
+
+	pb "galaxy.io/server/proto"
+)
+
+// announce broadcasts a notable moment to every player, with its template
+// filled in. Moments with an empty template are left out.
+func (w *World) announce(kind pb.FeedKind, template string, player *Player, other *Player, radius uint32) {
+	if template == "" {
+		return
+	}
+
+	replacements := []string{"{player}", player.Username, "{radius}", strconv.FormatUint(uint64(radius), 10)}
+	feed := &pb.FeedEvent{
+		Kind:     kind.Enum(),
+		PlayerID: player.PlayerID[:],
+	}
+	if other != nil {
+		replacements = append(replacements, "{victim}", other.Username)
+		feed.OtherPlayerID = other.PlayerID[:]
+	}
+	if radius > 0 {
+		feed.Radius = &radius
+	}
+	message := strings.NewReplacer(replacements...).Replace(template)
+	feed.Message = &message
+
+	w.playerLogger(player).Debug("announcing", "kind", kind, "message", message)
+	w.broadcastEvent(&pb.Event{
+		EventType: pb.EventType_EvFeed.Enum(),
+		EventData: &pb.Event_FeedEvent{
+			FeedEvent: feed,
+		},
+	})
+}
+
+// announceKill tells everyone that eater ate eaten.
+func (w *World) announceKill(eater *Player, eaten *Player) {
+	if eaten.IsBot() {
+		w.announce(pb.FeedKind_FeedBotEaten, w.config.Feed.BotEaten, eater, eaten, 0)
+		return
+	}
+	w.announce(pb.FeedKind_FeedKill, w.config.Feed.Kill, eater, eaten, 0)
+}
+
+// announceMilestones tells everyone about the milestones a player went
+// past while growing from before to after.
+func (w *World) announceMilestones(player *Player, before uint32, after uint32) {
+	for _, milestone := range w.config.Feed.Milestones {
+		if before < milestone && milestone <= after {
+			w.announce(pb.FeedKind_FeedMilestone, w.config.Feed.Milestone, player, nil, milestone)
+		}
+	}
+}
+
+// announceLeader tells everyone when a different player is first in the
+// leaderboard. Only called from trackLeader, which owns lastLeader.
+func (w *World) announceLeader(leader *Player) {
+	if leader.PlayerID == w.lastLeader {
+		return
+	}
+	w.lastLeader = leader.PlayerID
+	w.announce(pb.FeedKind_FeedNewLeader, w.config.Feed.NewLeader, leader, nil, 0)
+}
@@ -1,6 +1,6 @@
 package galaxy
 
-import "math/rand"
+import "galaxy.io/server/config"
 
 // Colors
 const (
@@ -43,20 +43,19 @@ type Food struct {
 	color    uint32
 }
 
-func createRandomFood() []Food {
-	// 100 comidas
+func createRandomFood(rng *Random, world config.WorldConfig) []Food {
 	var food []Food
-	for i := 0; i < 800; i++ {
+	for i := 0; i < world.Food; i++ {
 		food = append(food, Food{
-			position: *randomPosition(),
-			color: randomColor(),
+			position: *randomPosition(rng, world),
+			color: randomColor(rng),
 		})
 	}
 
 	return food
 }
 
-func randomColor() uint32 {
-	randomIndex := rand.Intn(len(FoodColors))
+func randomColor(rng *Random) uint32 {
+	randomIndex := rng.IntN(len(FoodColors))
 	return FoodColors[randomIndex]
 }
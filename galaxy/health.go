@@ -0,0 +1,101 @@
+package galaxy
+
+import (
+	"net/http"
+)
+
+// WorldStatus is served by /status.
+type WorldStatus struct {
+	// Either "public" or "private".
+	Mode    string  `json:"mode"`
+	GameID  *uint32 `json:"gameId,omitempty"`
+	Players int     `json:"players"`
+	Humans  int     `json:"humans"`
+	Bots    int     `json:"bots"`
+	// Seconds since the world was created.
+	Uptime       float64 `json:"uptime"`
+	Paused       bool    `json:"paused"`
+	ShuttingDown bool    `json:"shuttingDown"`
+}
+
+// Readiness is served by /readyz.
+type Readiness struct {
+	Ready bool `json:"ready"`
+	// Why the world is not ready, if it isn't.
+	Reasons []string `json:"reasons,omitempty"`
+	// Outcome of the last request of every kind made to the backend.
+	Backend map[string]CallStatus `json:"backend"`
+}
+
+func (w *World) Status() WorldStatus {
+	stats := w.Stats()
+
+	mode := "public"
+	if w.privateServer {
+		mode = "private"
+	}
+
+	return WorldStatus{
+		Mode:         mode,
+		GameID:       stats.GameID,
+		Players:      stats.Humans + stats.Bots,
+		Humans:       stats.Humans,
+		Bots:         stats.Bots,
+		Uptime:       w.clock.Now().Sub(w.startedAt).Seconds(),
+		Paused:       w.paused.Load(),
+		ShuttingDown: w.shuttingDown.Load(),
+	}
+}
+
+// Readiness reports whether the world can take new players. Failed backend
+// calls are reported but don't make the world unready, as games can still
+// be played without the backend.
+func (w *World) Readiness() Readiness {
+	readiness := Readiness{
+		Ready:   true,
+		Backend: w.backend.LastCalls(),
+	}
+
+	if w.paused.Load() {
+		readiness.Reasons = append(readiness.Reasons, "paused")
+	}
+	if w.shuttingDown.Load() {
+		readiness.Reasons = append(readiness.Reasons, "shutting down")
+	}
+	if w.isFull() {
+		readiness.Reasons = append(readiness.Reasons, "full")
+	}
+
+	readiness.Ready = len(readiness.Reasons) == 0
+	return readiness
+}
+
+func (w *World) isFull() bool {
+	w.playersMutex.RLock()
+	eaten := w.eatenSpectators()
+	w.playersMutex.RUnlock()
+
+	// spectators that only watch don't take a place
+	return w.Stats().Humans+eaten >= w.config.World.MaxPlayers
+}
+
+// HandleHealthz reports that the process is alive.
+func (w *World) HandleHealthz(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain")
+	writer.Write([]byte("ok\n"))
+}
+
+// HandleReadyz fails with 503 while the world can't take new players.
+func (w *World) HandleReadyz(writer http.ResponseWriter, r *http.Request) {
+	readiness := w.Readiness()
+
+	status := http.StatusOK
+	if !readiness.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(writer, status, readiness)
+}
+
+func (w *World) HandleStatus(writer http.ResponseWriter, r *http.Request) {
+	writeJSON(writer, http.StatusOK, w.Status())
+}
@@ -0,0 +1,54 @@
+package galaxy
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// A paused private server reports it until a player starts the next game.
+func TestPausedUntilNextGame(t *testing.T) {
+	w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.Server.Private = true
+	})
+	join := func(gameID uint32) {
+		t.Helper()
+		player := NewPlayer(uuid.New(), newTestConnection(), w.rng, w.config.World)
+		w.registerPlayer(player)
+		playerID := uuid.New()
+		joined := run(func() {
+			w.operationJoin(player, &pb.JoinOperation{
+				PlayerID: playerID[:],
+				Username: proto.String("tester"),
+				Color:    proto.Uint32(Red),
+				GameID:   proto.Uint32(gameID),
+			})
+		})
+		advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, joined)
+	}
+	check := func(step string, want bool) {
+		t.Helper()
+		if got := w.Status().Paused; got != want {
+			t.Errorf("%s: got paused %v in the status, want %v", step, got, want)
+		}
+		if got := slices.Contains(w.Readiness().Reasons, "paused"); got != want {
+			t.Errorf("%s: got paused %v in the readiness, want %v", step, got, want)
+		}
+	}
+
+	join(7)
+	check("playing", false)
+
+	w.pauseServer()
+	check("paused", true)
+	clock.Advance(time.Minute)
+	check("still paused", true)
+
+	join(8)
+	check("next game", false)
+}
@@ -0,0 +1,119 @@
+package galaxy
+
+import (
+	"slices"
+
+	pb "galaxy.io/server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// runLeaderboard sends the leaderboard to every client once every
+// interval, until the world shuts down.
+func (w *World) runLeaderboard() {
+	for !w.shuttingDown.Load() {
+		w.clock.Sleep(w.config.Leaderboard.Interval)
+		w.sendLeaderboard()
+	}
+}
+
+// standings returns every player from biggest to smallest, with the rank
+// of each one. Players of the same size share a rank.
+func (w *World) standings() ([]*Player, []uint32) {
+	w.playersMutex.RLock()
+	players := make([]*Player, 0, len(w.players))
+	for _, player := range w.players {
+		players = append(players, player)
+	}
+	w.playersMutex.RUnlock()
+
+	radii := make(map[*Player]uint32, len(players))
+	for _, player := range players {
+		player.RLock()
+		radii[player] = player.Radius
+		player.RUnlock()
+	}
+	slices.SortFunc(players, func(a, b *Player) int {
+		return int(radii[b]) - int(radii[a])
+	})
+
+	ranks := make([]uint32, len(players))
+	for i, player := range players {
+		if i > 0 && radii[player] == radii[players[i-1]] {
+			ranks[i] = ranks[i-1]
+		} else {
+			ranks[i] = uint32(i + 1)
+		}
+	}
+	return players, ranks
+}
+
+// leaderOf returns the player alone in first place of the standings, nil
+// when the world is empty or the first place is shared.
+func leaderOf(players []*Player, ranks []uint32) *Player {
+	if len(players) == 0 || (len(players) > 1 && ranks[1] == 1) {
+		return nil
+	}
+	return players[0]
+}
+
+// rank returns the position of the player in the standings, after everyone
+// else when it is not playing.
+func (w *World) rank(player *Player) uint32 {
+	players, ranks := w.standings()
+	if i := slices.Index(players, player); i >= 0 {
+		return ranks[i]
+	}
+	return uint32(len(players) + 1)
+}
+
+// sendLeaderboard sends the top players to every client, along with the
+// rank of the client itself.
+func (w *World) sendLeaderboard() {
+	players, ranks := w.standings()
+
+	entries := make([]*pb.LeaderboardEntry, 0, w.config.Leaderboard.Size)
+	for _, player := range players[:min(len(players), w.config.Leaderboard.Size)] {
+		player.RLock()
+		entries = append(entries, &pb.LeaderboardEntry{
+			PlayerID: player.PlayerID[:],
+			Username: proto.String(player.Username),
+			Score:    proto.Uint32(player.Radius),
+		})
+		player.RUnlock()
+	}
+
+	leaderboard := func(rank *uint32) *pb.Event {
+		return &pb.Event{
+			EventType: pb.EventType_EvLeaderboard.Enum(),
+			EventData: &pb.Event_LeaderboardEvent{
+				LeaderboardEvent: &pb.LeaderboardEvent{
+					Entries: entries,
+					Rank:    rank,
+				},
+			},
+		}
+	}
+	w.recorder.RecordEvent(leaderboard(nil))
+
+	receivers := make([]*Player, 0, len(players))
+	receiverRanks := make([]*uint32, 0, len(players))
+	for i, player := range players {
+		if !player.IsBot() {
+			receivers = append(receivers, player)
+			receiverRanks = append(receiverRanks, &ranks[i])
+		}
+	}
+	w.playersMutex.RLock()
+	for _, spectator := range w.spectators {
+		receivers = append(receivers, spectator)
+		receiverRanks = append(receiverRanks, nil)
+	}
+	w.playersMutex.RUnlock()
+
+	for i, receiver := range receivers {
+		if err := w.sendEvent(receiver, leaderboard(receiverRanks[i])); err != nil {
+			w.playerLogger(receiver).Info("removing player after failing to send the leaderboard", "err", err)
+			w.removePlayer(receiver)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package galaxy
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+func TestStandings(t *testing.T) {
+	tests := []struct {
+		name      string
+		radii     []uint32
+		wantRadii []uint32
+		wantRanks []uint32
+		// Whether someone is alone in first place.
+		wantLeader bool
+	}{
+		{"empty world", nil, nil, nil, false},
+		{"alone", []uint32{50}, []uint32{50}, []uint32{1}, true},
+		{"biggest first", []uint32{30, 90, 60}, []uint32{90, 60, 30}, []uint32{1, 2, 3}, true},
+		{"same size shares a rank", []uint32{50, 80, 30, 50}, []uint32{80, 50, 50, 30}, []uint32{1, 2, 2, 4}, true},
+		{"shared first place", []uint32{80, 40, 80}, []uint32{80, 80, 40}, []uint32{1, 1, 3}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, _, _ := newTestWorld(t, nil)
+			for i, radius := range test.radii {
+				addTestPlayer(w, Vector2D{X: uint32(i) * 1000, Y: 1000}, radius)
+			}
+
+			players, ranks := w.standings()
+			var radii []uint32
+			for _, player := range players {
+				radii = append(radii, player.Radius)
+			}
+			if !slices.Equal(radii, test.wantRadii) {
+				t.Errorf("got radii %v, want %v", radii, test.wantRadii)
+			}
+			if !slices.Equal(ranks, test.wantRanks) {
+				t.Errorf("got ranks %v, want %v", ranks, test.wantRanks)
+			}
+			if leader := leaderOf(players, ranks); (leader != nil) != test.wantLeader {
+				t.Errorf("got leader %v, want one %v", leader, test.wantLeader)
+			}
+			for i, player := range players {
+				if rank := w.rank(player); rank != ranks[i] {
+					t.Errorf("got rank %d for a radius of %d, want %d", rank, player.Radius, ranks[i])
+				}
+			}
+			outside := NewPlayer(uuid.New(), newTestConnection(), w.rng, w.config.World)
+			if rank := w.rank(outside); rank != uint32(len(players)+1) {
+				t.Errorf("got rank %d for a player not playing, want %d", rank, len(players)+1)
+			}
+		})
+	}
+}
+
+func TestTrackLeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		radii         [2]uint32
+		wantTimeAtTop [2]time.Duration
+		wantAnnounced int
+	}{
+		{"the biggest player", [2]uint32{100, 50}, [2]time.Duration{3 * time.Second, 0}, 1},
+		{"nobody on a tie", [2]uint32{100, 100}, [2]time.Duration{0, 0}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.Leaderboard.Interval = time.Hour
+			})
+			first, _ := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, test.radii[0])
+			second, conn := addTestPlayer(w, Vector2D{X: 5000, Y: 5000}, test.radii[1])
+
+			clock.Advance(3 * LEADER_TRACK_INTERVAL)
+
+			for i, player := range []*Player{first, second} {
+				player.Stats.Lock()
+				timeAtTop := player.Stats.TimeAtTop
+				player.Stats.Unlock()
+				if timeAtTop != test.wantTimeAtTop[i] {
+					t.Errorf("player %d got %v at the top, want %v", i, timeAtTop, test.wantTimeAtTop[i])
+				}
+			}
+			var announced int
+			for _, event := range conn.received(pb.EventType_EvFeed) {
+				if event.GetFeedEvent().GetKind() == pb.FeedKind_FeedNewLeader {
+					announced++
+				}
+			}
+			if announced != test.wantAnnounced {
+				t.Errorf("got %d new leaders announced, want %d", announced, test.wantAnnounced)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package galaxy
+
+import "galaxy.io/server/metrics"
+
+var (
+	operationsTotal = metrics.NewCounterVec(
+		"galaxy_operations_total",
+		"Operations received from players, by operation type.",
+		"type",
+	)
+	broadcastDuration = metrics.NewHistogram(
+		"galaxy_broadcast_duration_seconds",
+		"Time taken to fan out an event to every player.",
+		metrics.DefaultBuckets,
+	)
+	botGoroutines = metrics.NewGauge(
+		"galaxy_bot_goroutines",
+		"Bot goroutines currently running.",
+	)
+	backendDuration = metrics.NewHistogramVec(
+		"galaxy_backend_request_duration_seconds",
+		"Latency of the requests made to the backend, by call.",
+		"call",
+		metrics.DefaultBuckets,
+	)
+	backendFailures = metrics.NewCounterVec(
+		"galaxy_backend_request_failures_total",
+		"Requests to the backend that errored or didn't return a 200, by call.",
+		"call",
+	)
+	outboxRetries = metrics.NewCounter(
+		"galaxy_outbox_retries_total",
+		"Backend calls from the outbox that failed and were scheduled again.",
+	)
+	outboxDeadLetters = metrics.NewCounter(
+		"galaxy_outbox_dead_letters_total",
+		"Backend calls from the outbox given up on after too many attempts.",
+	)
+)
+
+// registerMetrics exposes the size of the outbox as gauges.
+func (o *Outbox) registerMetrics() {
+	metrics.NewGaugeFunc("galaxy_outbox_pending", "Backend calls waiting in the outbox.", func() float64 {
+		return float64(o.Pending())
+	})
+	metrics.NewGaugeFunc("galaxy_outbox_dead", "Dead letters waiting to be retried or discarded.", func() float64 {
+		return float64(len(o.Status().Dead))
+	})
+}
+
+// registerMetrics exposes the state of the world as gauges.
+func (w *World) registerMetrics() {
+	metrics.NewGaugeFunc("galaxy_connected_humans", "Human players in the world.", func() float64 {
+		return float64(w.Stats().Humans)
+	})
+	metrics.NewGaugeFunc("galaxy_connected_bots", "Bots in the world.", func() float64 {
+		return float64(w.Stats().Bots)
+	})
+	metrics.NewGaugeFunc("galaxy_food", "Food items in the world.", func() float64 {
+		w.foodMutex.RLock()
+		defer w.foodMutex.RUnlock()
+		return float64(len(w.food))
+	})
+}
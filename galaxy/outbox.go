@@ -0,0 +1,592 @@
+package galaxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"galaxy.io/server/config"
+	"github.com/google/uuid"
+)
+
+var ErrorEntryNotFound = errors.New("outbox entry not found")
+
+// OutboxEntry is a call to the backend waiting to be delivered.
+type OutboxEntry struct {
+	// Also used as the idempotency key of the call.
+	ID           string        `json:"id"`
+	Call         string        `json:"call"`
+	GameID       uint32        `json:"gameId,omitempty"`
+	Players      []PlayerData  `json:"players,omitempty"`
+	Achievements []Achievement `json:"achievements,omitempty"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	Attempts     int           `json:"attempts"`
+	NextAttempt  time.Time     `json:"nextAttempt"`
+	LastError    string        `json:"lastError,omitempty"`
+}
+
+// OutboxStatus describes the outbox in the admin API.
+type OutboxStatus struct {
+	Pending int           `json:"pending"`
+	Dead    []OutboxEntry `json:"dead"`
+}
+
+// Outbox is a Backend that queues every call changing the backend and
+// delivers them from its own goroutine, retrying failed calls with
+// exponential backoff. The calls of a private game are delivered in order,
+// the rest as soon as they are due. Calls the backend rejects, and those
+// that keep failing, are set aside as dead letters until an administrator
+// retries or discards them.
+//
+// Achievements are queued as soon as they are posted and delivered once the
+// flush interval has passed, together with the achievements queued after
+// them, in batches of at most the batch size.
+//
+// When a directory is configured the queue is kept there, one file per
+// entry, so calls survive a restart of the server.
+type Outbox struct {
+	backend       Backend
+	config        config.OutboxConfig
+	flushInterval time.Duration
+	batchSize     int
+	clock         Clock
+
+	mutex   sync.Mutex
+	pending []*OutboxEntry
+	dead    []*OutboxEntry
+	// Closed and replaced every time an entry leaves pending.
+	taken chan struct{}
+
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+const (
+	outboxPendingDir = "pending"
+	outboxDeadDir    = "dead"
+)
+
+func NewOutbox(backend Backend, backendConfig config.BackendConfig, clock Clock) (*Outbox, error) {
+	cfg := backendConfig.Outbox
+	o := &Outbox{
+		backend:       backend,
+		config:        cfg,
+		flushInterval: backendConfig.AchievementFlushInterval,
+		batchSize:     backendConfig.AchievementBatchSize,
+		clock:         clock,
+		taken:         make(chan struct{}),
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+
+	if cfg.Dir != "" {
+		var err error
+		if o.pending, err = loadOutboxEntries(filepath.Join(cfg.Dir, outboxPendingDir)); err != nil {
+			return nil, err
+		}
+		if o.dead, err = loadOutboxEntries(filepath.Join(cfg.Dir, outboxDeadDir)); err != nil {
+			return nil, err
+		}
+		if len(o.pending) > 0 || len(o.dead) > 0 {
+			slog.Info("loaded outbox", "dir", cfg.Dir, "pending", len(o.pending), "dead", len(o.dead))
+		}
+	}
+
+	o.registerMetrics()
+	go o.run()
+	return o, nil
+}
+
+func loadOutboxEntries(dir string) ([]*OutboxEntry, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create outbox directory: %w", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*OutboxEntry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		entry := &OutboxEntry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			slog.Error("skipping unreadable outbox entry", "path", path, "err", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+func (o *Outbox) path(dir string, entry *OutboxEntry) string {
+	return filepath.Join(o.config.Dir, dir, entry.ID+".json")
+}
+
+// save writes the entry to a temporary file and renames it in place, so a
+// crash never leaves a half written entry behind.
+func (o *Outbox) save(dir string, entry *OutboxEntry) error {
+	if o.config.Dir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := o.path(dir, entry)
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+func (o *Outbox) remove(dir string, entry *OutboxEntry) {
+	if o.config.Dir == "" {
+		return
+	}
+
+	if err := os.Remove(o.path(dir, entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Error("unable to remove outbox entry", "id", entry.ID, "err", err)
+	}
+}
+
+func (o *Outbox) signal() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) enqueue(entry *OutboxEntry) {
+	entry.ID = uuid.NewString()
+	entry.CreatedAt = o.clock.Now()
+
+	// saved before taking the lock, as the world calls it while holding its
+	// own locks, and nothing else sees the entry until it is pending
+	if err := o.save(outboxPendingDir, entry); err != nil {
+		slog.Error("unable to persist outbox entry, keeping it in memory", "id", entry.ID, "call", entry.Call, "err", err)
+	}
+
+	o.mutex.Lock()
+	o.pending = append(o.pending, entry)
+	o.mutex.Unlock()
+
+	o.signal()
+}
+
+func (o *Outbox) StartPrivateGame(ctx context.Context, gameID uint32) error {
+	o.enqueue(&OutboxEntry{Call: "startPrivateGame", GameID: gameID})
+	return nil
+}
+
+func (o *Outbox) PausePrivateGame(ctx context.Context, gameID uint32) error {
+	o.enqueue(&OutboxEntry{Call: "pausePrivateGame", GameID: gameID})
+	return nil
+}
+
+// GetValues asks the backend right away, as the players are needed to
+// continue the game, once the start of the game queued before it has been
+// delivered. Players uploaded but not delivered yet are returned instead,
+// as the backend doesn't know about them.
+func (o *Outbox) GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error) {
+	o.mutex.Lock()
+	for _, entry := range slices.Backward(o.pending) {
+		if entry.Call == "uploadValues" && entry.GameID == gameID {
+			players := slices.Clone(entry.Players)
+			o.mutex.Unlock()
+			return players, nil
+		}
+	}
+	o.mutex.Unlock()
+
+	err := o.waitFor(ctx, func(entry *OutboxEntry) bool {
+		return entry.Call == "startPrivateGame" && entry.GameID == gameID
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start of game %d not delivered yet: %w", gameID, err)
+	}
+	return o.backend.GetValues(ctx, gameID)
+}
+
+// waitFor waits until no pending entry matches, or ctx is done.
+func (o *Outbox) waitFor(ctx context.Context, match func(entry *OutboxEntry) bool) error {
+	for {
+		o.mutex.Lock()
+		pending := slices.ContainsFunc(o.pending, match)
+		taken := o.taken
+		o.mutex.Unlock()
+
+		if !pending {
+			return nil
+		}
+		select {
+		case <-taken:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (o *Outbox) UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error {
+	o.enqueue(&OutboxEntry{Call: "uploadValues", GameID: gameID, Players: players})
+	return nil
+}
+
+// PostAchievements queues the achievements to be posted with the next
+// batch, right away if a batch is full.
+func (o *Outbox) PostAchievements(ctx context.Context, achievements []Achievement) error {
+	next := o.clock.Now().Add(o.flushInterval)
+
+	o.mutex.Lock()
+	queued := len(achievements)
+	for _, entry := range o.pending {
+		if entry.batched() {
+			queued += len(entry.Achievements)
+		}
+	}
+	o.mutex.Unlock()
+	if queued >= o.batchSize {
+		next = time.Time{}
+	}
+
+	o.enqueue(&OutboxEntry{Call: "postAchievements", Achievements: achievements, NextAttempt: next})
+	return nil
+}
+
+func (o *Outbox) call(entry *OutboxEntry) error {
+	ctx := WithIdempotencyKey(context.Background(), entry.ID)
+	switch entry.Call {
+	case "startPrivateGame":
+		return o.backend.StartPrivateGame(ctx, entry.GameID)
+	case "pausePrivateGame":
+		return o.backend.PausePrivateGame(ctx, entry.GameID)
+	case "uploadValues":
+		return o.backend.UploadValues(ctx, entry.GameID, entry.Players)
+	case "postAchievements":
+		return o.backend.PostAchievements(ctx, entry.Achievements)
+	default:
+		return fmt.Errorf("unknown call %q", entry.Call)
+	}
+}
+
+// batched reports whether the entry holds achievements that can still be
+// batched with others: once attempted, the backend may have applied them
+// under the ID of the entry.
+func (e *OutboxEntry) batched() bool {
+	return e.Call == "postAchievements" && e.Attempts == 0
+}
+
+// ordered reports whether the entry has to wait for the calls of its game
+// queued before it. Achievements can be posted in any order.
+func (e *OutboxEntry) ordered() bool {
+	return e.Call != "postAchievements"
+}
+
+// next returns the entry due first, leaving out the calls of a game that
+// are behind another call of the same game, so a game is never paused in
+// the backend before it was started. The lock must be held.
+func (o *Outbox) next() *OutboxEntry {
+	var next *OutboxEntry
+	queued := make(map[uint32]bool)
+	for _, entry := range o.pending {
+		if entry.ordered() {
+			if queued[entry.GameID] {
+				continue
+			}
+			queued[entry.GameID] = true
+		}
+		if next == nil || entry.NextAttempt.Before(next.NextAttempt) {
+			next = entry
+		}
+	}
+	return next
+}
+
+// run delivers the entries as they come due. A failing call only holds
+// back the calls of its own game.
+func (o *Outbox) run() {
+	for {
+		o.mutex.Lock()
+		entry := o.next()
+		o.mutex.Unlock()
+
+		if entry == nil {
+			select {
+			case <-o.wake:
+			case <-o.done:
+				return
+			}
+			continue
+		}
+
+		if wait := entry.NextAttempt.Sub(o.clock.Now()); wait > 0 {
+			timer := o.clock.NewTimer(wait)
+			select {
+			case <-timer.C():
+			case <-o.wake:
+				timer.Stop()
+			case <-o.done:
+				timer.Stop()
+				return
+			}
+			continue
+		}
+
+		o.deliver(entry)
+	}
+}
+
+func (o *Outbox) deliver(entry *OutboxEntry) {
+	if entry.batched() {
+		o.mutex.Lock()
+		o.batch(entry)
+		o.mutex.Unlock()
+	}
+
+	err := o.call(entry)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err == nil {
+		o.take(entry)
+		o.remove(outboxPendingDir, entry)
+		return
+	}
+
+	var partial *AchievementsError
+	if errors.As(err, &partial) && len(partial.Failed) < len(entry.Achievements) {
+		o.narrow(entry, partial.Failed)
+	}
+
+	entry.Attempts++
+	entry.LastError = err.Error()
+	logger := slog.With("id", entry.ID, "call", entry.Call, "attempts", entry.Attempts, "err", err)
+
+	if entry.Attempts >= o.config.MaxAttempts || permanent(err) {
+		logger.Error("giving up on backend call, moving it to the dead letters")
+		o.take(entry)
+		o.dead = append(o.dead, entry)
+		if err := o.save(outboxDeadDir, entry); err != nil {
+			logger.Error("unable to persist dead letter", "saveErr", err)
+		}
+		o.remove(outboxPendingDir, entry)
+		outboxDeadLetters.Inc()
+		return
+	}
+
+	backoff := o.backoff(entry.Attempts)
+	entry.NextAttempt = o.clock.Now().Add(backoff)
+	logger.Warn("backend call failed, retrying", "in", backoff)
+	if err := o.save(outboxPendingDir, entry); err != nil {
+		logger.Error("unable to persist outbox entry", "saveErr", err)
+	}
+	outboxRetries.Inc()
+}
+
+// batch moves into the entry the achievements queued after it, as long as
+// they fit in a batch. The entry is saved before the others are removed, so
+// a crash in between may post some achievements twice but never loses them.
+// The lock must be held.
+func (o *Outbox) batch(entry *OutboxEntry) {
+	var merged []*OutboxEntry
+	achievements := slices.Clone(entry.Achievements)
+	for _, other := range o.pending {
+		if other == entry || !other.batched() || len(achievements)+len(other.Achievements) > o.batchSize {
+			continue
+		}
+		achievements = append(achievements, other.Achievements...)
+		merged = append(merged, other)
+	}
+	if len(merged) == 0 {
+		return
+	}
+
+	entry.Achievements = achievements
+	if err := o.save(outboxPendingDir, entry); err != nil {
+		slog.Error("unable to persist batch of achievements", "id", entry.ID, "err", err)
+	}
+	for _, other := range merged {
+		o.take(other)
+		o.remove(outboxPendingDir, other)
+	}
+	slog.Debug("posting achievements", "count", len(achievements), "entries", len(merged)+1)
+}
+
+// take removes an entry from pending and wakes whoever waits for it. The
+// lock must be held.
+func (o *Outbox) take(entry *OutboxEntry) {
+	o.pending = slices.DeleteFunc(o.pending, func(e *OutboxEntry) bool { return e == entry })
+	close(o.taken)
+	o.taken = make(chan struct{})
+}
+
+// permanent reports whether retrying a call can't help, as the backend
+// rejected it with a client error other than a timeout or a rate limit.
+// Joined errors are permanent only if all of them are.
+func permanent(err error) bool {
+	if status, ok := err.(*errorStatus); ok {
+		return status.code >= 400 && status.code < 500 &&
+			status.code != http.StatusRequestTimeout && status.code != http.StatusTooManyRequests
+	}
+	switch err := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, err := range err.Unwrap() {
+			if !permanent(err) {
+				return false
+			}
+		}
+		return len(err.Unwrap()) > 0
+	case interface{ Unwrap() error }:
+		return permanent(err.Unwrap())
+	}
+	return false
+}
+
+// narrow leaves in the entry only the achievements that were not posted.
+// Their positions changed, so the entry gets a new ID and with it new
+// idempotency keys. The lock must be held.
+func (o *Outbox) narrow(entry *OutboxEntry, failed []Achievement) {
+	slog.Info("some achievements were posted, retrying the rest", "id", entry.ID, "posted", len(entry.Achievements)-len(failed), "failed", len(failed))
+	o.remove(outboxPendingDir, entry)
+	entry.ID = uuid.NewString()
+	entry.Achievements = failed
+}
+
+// backoff doubles the wait after every attempt up to the configured maximum,
+// and picks a random duration between half of it and all of it so servers
+// don't retry in lockstep.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	backoff := o.config.MinBackoff
+	for i := 1; i < attempts && backoff < o.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, o.config.MaxBackoff)
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// Pending returns the number of entries waiting to be delivered.
+func (o *Outbox) Pending() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return len(o.pending)
+}
+
+func (o *Outbox) Status() OutboxStatus {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	status := OutboxStatus{
+		Pending: len(o.pending),
+		Dead:    make([]OutboxEntry, 0, len(o.dead)),
+	}
+	for _, entry := range o.dead {
+		status.Dead = append(status.Dead, *entry)
+	}
+	return status
+}
+
+func (o *Outbox) takeDead(id string) (*OutboxEntry, error) {
+	index := slices.IndexFunc(o.dead, func(e *OutboxEntry) bool { return e.ID == id })
+	if index < 0 {
+		return nil, ErrorEntryNotFound
+	}
+	entry := o.dead[index]
+	o.dead = slices.Delete(o.dead, index, index+1)
+	o.remove(outboxDeadDir, entry)
+	return entry, nil
+}
+
+// Retry queues a dead letter again, with its attempts reset.
+func (o *Outbox) Retry(id string) error {
+	o.mutex.Lock()
+	entry, err := o.takeDead(id)
+	if err != nil {
+		o.mutex.Unlock()
+		return err
+	}
+
+	slog.Info("retrying dead letter", "id", entry.ID, "call", entry.Call)
+	entry.Attempts = 0
+	entry.NextAttempt = time.Time{}
+	if err := o.save(outboxPendingDir, entry); err != nil {
+		slog.Error("unable to persist outbox entry, keeping it in memory", "id", entry.ID, "err", err)
+	}
+	o.pending = append(o.pending, entry)
+	o.mutex.Unlock()
+
+	o.signal()
+	return nil
+}
+
+// Discard drops a dead letter for good.
+func (o *Outbox) Discard(id string) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	entry, err := o.takeDead(id)
+	if err != nil {
+		return err
+	}
+	slog.Warn("discarded dead letter", "id", entry.ID, "call", entry.Call)
+	return nil
+}
+
+// Close posts the queued achievements without waiting for the flush
+// interval, waits for the queued calls to be delivered and stops the outbox.
+// Once ctx is done it gives up, leaving the remaining calls on disk to be
+// delivered on the next start.
+func (o *Outbox) Close(ctx context.Context) error {
+	defer o.closeOnce.Do(func() { close(o.done) })
+
+	o.mutex.Lock()
+	for _, entry := range o.pending {
+		if entry.batched() {
+			entry.NextAttempt = time.Time{}
+		}
+	}
+	o.mutex.Unlock()
+	o.signal()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for o.Pending() > 0 {
+		select {
+		case <-ctx.Done():
+			slog.Warn("outbox not drained before shutting down", "pending", o.Pending(), "persisted", o.config.Dir != "")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package galaxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+)
+
+// stubBackend is a MemoryBackend that records the calls it gets, failing
+// the ones fail returns an error for.
+type stubBackend struct {
+	*MemoryBackend
+
+	mutex     sync.Mutex
+	fail      func(call string, gameID uint32) error
+	delivered []string
+	// Number of achievements in every batch posted.
+	batches []int
+}
+
+func newStubBackend(fail func(call string, gameID uint32) error) *stubBackend {
+	if fail == nil {
+		fail = func(string, uint32) error { return nil }
+	}
+	return &stubBackend{MemoryBackend: NewMemoryBackend(), fail: fail}
+}
+
+func (s *stubBackend) call(call string, gameID uint32) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.fail(call, gameID); err != nil {
+		return err
+	}
+	s.delivered = append(s.delivered, fmt.Sprintf("%s %d", call, gameID))
+	return nil
+}
+
+func (s *stubBackend) calls() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.delivered)
+}
+
+func (s *stubBackend) StartPrivateGame(ctx context.Context, gameID uint32) error {
+	if err := s.call("startPrivateGame", gameID); err != nil {
+		return err
+	}
+	return s.MemoryBackend.StartPrivateGame(ctx, gameID)
+}
+
+func (s *stubBackend) PausePrivateGame(ctx context.Context, gameID uint32) error {
+	if err := s.call("pausePrivateGame", gameID); err != nil {
+		return err
+	}
+	return s.MemoryBackend.PausePrivateGame(ctx, gameID)
+}
+
+func (s *stubBackend) GetValues(ctx context.Context, gameID uint32) ([]PlayerData, error) {
+	if err := s.call("getValues", gameID); err != nil {
+		return nil, err
+	}
+	return s.MemoryBackend.GetValues(ctx, gameID)
+}
+
+func (s *stubBackend) UploadValues(ctx context.Context, gameID uint32, players []PlayerData) error {
+	if err := s.call("uploadValues", gameID); err != nil {
+		return err
+	}
+	return s.MemoryBackend.UploadValues(ctx, gameID, players)
+}
+
+func (s *stubBackend) PostAchievements(ctx context.Context, achievements []Achievement) error {
+	if err := s.call("postAchievements", 0); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.batches = append(s.batches, len(achievements))
+	s.mutex.Unlock()
+	return s.MemoryBackend.PostAchievements(ctx, achievements)
+}
+
+func (s *stubBackend) batchSizes() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.batches)
+}
+
+// failing fails a call of a game with status the given number of times,
+// forever when times is negative.
+func failing(call string, gameID uint32, code int, times int) func(string, uint32) error {
+	return func(c string, g uint32) error {
+		if c != call || g != gameID || times == 0 {
+			return nil
+		}
+		times--
+		return &errorStatus{path: c, code: code, status: http.StatusText(code)}
+	}
+}
+
+func newTestOutbox(t *testing.T, backend Backend, maxAttempts int) (*Outbox, *ManualClock) {
+	return newBatchingOutbox(t, backend, maxAttempts, 0, 0)
+}
+
+// newBatchingOutbox returns an outbox that batches achievements, driven by a
+// manual clock.
+func newBatchingOutbox(t *testing.T, backend Backend, maxAttempts int, flushInterval time.Duration, batchSize int) (*Outbox, *ManualClock) {
+	t.Helper()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	outbox, err := NewOutbox(backend, config.BackendConfig{
+		AchievementFlushInterval: flushInterval,
+		AchievementBatchSize:     batchSize,
+		Outbox:                   config.OutboxConfig{MinBackoff: time.Second, MaxBackoff: 10 * time.Second, MaxAttempts: maxAttempts},
+	}, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		outbox.Close(ctx)
+	})
+	return outbox, clock
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		fail        func(call string, gameID uint32) error
+		maxAttempts int
+		queue       func(o *Outbox)
+		want        []string
+		wantPending int
+		wantDead    int
+	}{
+		{
+			name:        "a failing game holds back only its own calls",
+			fail:        failing("startPrivateGame", 1, http.StatusServiceUnavailable, -1),
+			maxAttempts: 20,
+			queue: func(o *Outbox) {
+				o.StartPrivateGame(ctx, 1)
+				o.PausePrivateGame(ctx, 1)
+				o.StartPrivateGame(ctx, 2)
+				o.PostAchievements(ctx, testAchievements)
+			},
+			want:        []string{"startPrivateGame 2", "postAchievements 0"},
+			wantPending: 2,
+		},
+		{
+			name:        "the calls of a game keep their order",
+			fail:        failing("startPrivateGame", 1, http.StatusServiceUnavailable, 2),
+			maxAttempts: 20,
+			queue: func(o *Outbox) {
+				o.StartPrivateGame(ctx, 1)
+				o.UploadValues(ctx, 1, nil)
+				o.PausePrivateGame(ctx, 1)
+			},
+			want: []string{"startPrivateGame 1", "uploadValues 1", "pausePrivateGame 1"},
+		},
+		{
+			name:        "client errors are dead letters right away",
+			fail:        failing("postAchievements", 0, http.StatusBadRequest, -1),
+			maxAttempts: 20,
+			queue: func(o *Outbox) {
+				o.PostAchievements(ctx, testAchievements)
+				o.StartPrivateGame(ctx, 1)
+			},
+			want:     []string{"startPrivateGame 1"},
+			wantDead: 1,
+		},
+		{
+			name:        "rate limits are retried",
+			fail:        failing("startPrivateGame", 1, http.StatusTooManyRequests, 2),
+			maxAttempts: 20,
+			queue: func(o *Outbox) {
+				o.StartPrivateGame(ctx, 1)
+			},
+			want: []string{"startPrivateGame 1"},
+		},
+		{
+			name:        "gives up after the last attempt",
+			fail:        failing("startPrivateGame", 1, http.StatusBadGateway, -1),
+			maxAttempts: 3,
+			queue: func(o *Outbox) {
+				o.StartPrivateGame(ctx, 1)
+				o.PausePrivateGame(ctx, 1)
+			},
+			want:     []string{"pausePrivateGame 1"},
+			wantDead: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := newStubBackend(test.fail)
+			outbox, clock := newTestOutbox(t, backend, test.maxAttempts)
+
+			test.queue(outbox)
+			advanceUntil(t, clock, time.Second, 10*time.Minute, func() bool {
+				status := outbox.Status()
+				return len(backend.calls()) == len(test.want) && status.Pending == test.wantPending && len(status.Dead) == test.wantDead
+			})
+
+			if calls := backend.calls(); !slices.Equal(calls, test.want) {
+				t.Errorf("delivered %v, want %v", calls, test.want)
+			}
+		})
+	}
+}
+
+func TestOutboxGetValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		fail    func(call string, gameID uint32) error
+		timeout time.Duration
+		want    []string
+		wantErr bool
+	}{
+		{"after the start", failing("startPrivateGame", 1, http.StatusServiceUnavailable, 2), time.Minute, []string{"startPrivateGame 1", "getValues 1"}, false},
+		{"not before the start", failing("startPrivateGame", 1, http.StatusServiceUnavailable, -1), 50 * time.Millisecond, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := newStubBackend(test.fail)
+			outbox, clock := newTestOutbox(t, backend, 20)
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			outbox.StartPrivateGame(ctx, 1)
+			var err error
+			loaded := run(func() {
+				_, err = outbox.GetValues(ctx, 1)
+			})
+			advanceUntil(t, clock, time.Second, 10*time.Minute, loaded)
+
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+			if test.wantErr && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got error %v, want the deadline", err)
+			}
+			if calls := backend.calls(); !slices.Equal(calls, test.want) {
+				t.Errorf("delivered %v, want %v", calls, test.want)
+			}
+		})
+	}
+}
+
+// Calls queued while a failed one waits for its retry don't leave timers
+// behind.
+func TestOutboxRetryTimer(t *testing.T) {
+	ctx := context.Background()
+	backend := newStubBackend(failing("startPrivateGame", 1, http.StatusServiceUnavailable, -1))
+	outbox, clock := newTestOutbox(t, backend, 20)
+
+	outbox.StartPrivateGame(ctx, 1)
+	advanceUntil(t, clock, time.Nanosecond, time.Microsecond, func() bool {
+		return clock.Sleepers() == 1
+	})
+	for gameID := uint32(2); gameID < 12; gameID++ {
+		outbox.StartPrivateGame(ctx, gameID)
+		advanceUntil(t, clock, time.Nanosecond, time.Microsecond, func() bool {
+			return len(backend.calls()) == int(gameID-1) && clock.Sleepers() == 1
+		})
+	}
+	if sleepers := clock.Sleepers(); sleepers != 1 {
+		t.Errorf("got %d timers, want 1", sleepers)
+	}
+}
+
+func TestOutboxBatchesAchievements(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		batchSize int
+		// Number of achievements in every call, all made at once.
+		posts []int
+		// Batches posted before and after the flush interval.
+		wantRightAway []int
+		want          []int
+	}{
+		{"one batch after the interval", 10, []int{3, 3, 3}, nil, []int{9}},
+		{"a full batch right away", 5, []int{2, 2, 2}, []int{4}, []int{4, 2}},
+		{"never over the batch size", 4, []int{3, 3}, []int{3}, []int{3, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := newStubBackend(nil)
+			outbox, clock := newBatchingOutbox(t, backend, 20, 10*time.Second, test.batchSize)
+
+			for _, count := range test.posts {
+				achievements := make([]Achievement, count)
+				for i := range achievements {
+					achievements[i] = Achievement{UserID: "a", Kind: ACHIEVEMENT_FOOD_EATEN, Quantity: uint32(i)}
+				}
+				outbox.PostAchievements(ctx, achievements)
+			}
+			advanceUntil(t, clock, time.Nanosecond, time.Microsecond, func() bool {
+				return len(backend.batchSizes()) == len(test.wantRightAway)
+			})
+			clock.Advance(9 * time.Second)
+			if batches := backend.batchSizes(); !slices.Equal(batches, test.wantRightAway) {
+				t.Errorf("posted batches of %v before the interval, want %v", batches, test.wantRightAway)
+			}
+
+			advanceUntil(t, clock, time.Second, time.Minute, func() bool {
+				return outbox.Pending() == 0
+			})
+			if batches := backend.batchSizes(); !slices.Equal(batches, test.want) {
+				t.Errorf("posted batches of %v, want %v", batches, test.want)
+			}
+		})
+	}
+}
+
+// Closing the outbox doesn't wait for the flush interval.
+func TestOutboxCloseFlushesAchievements(t *testing.T) {
+	backend := newStubBackend(nil)
+	outbox, _ := newBatchingOutbox(t, backend, 20, time.Minute, 100)
+	outbox.PostAchievements(context.Background(), testAchievements)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := outbox.Close(ctx); err != nil {
+		t.Fatalf("closing the outbox: %v", err)
+	}
+	if batches := backend.batchSizes(); !slices.Equal(batches, []int{len(testAchievements)}) {
+		t.Errorf("posted batches of %v, want %v", batches, []int{len(testAchievements)})
+	}
+}
+
+func TestMemoryBackendIdempotency(t *testing.T) {
+	withKey := func(key string) context.Context {
+		return WithIdempotencyKey(context.Background(), key)
+	}
+	tests := []struct {
+		name             string
+		calls            func(m *MemoryBackend)
+		wantRunning      bool
+		wantPlayers      []string
+		wantAchievements int
+	}{
+		{
+			name: "an old upload retried keeps the newer one",
+			calls: func(m *MemoryBackend) {
+				m.UploadValues(withKey("1"), 1, []PlayerData{{PlayerID: "old"}})
+				m.UploadValues(withKey("2"), 1, []PlayerData{{PlayerID: "new"}})
+				m.UploadValues(withKey("1"), 1, []PlayerData{{PlayerID: "old"}})
+			},
+			wantPlayers: []string{"new"},
+		},
+		{
+			name: "a start retried after the pause",
+			calls: func(m *MemoryBackend) {
+				m.StartPrivateGame(withKey("1"), 1)
+				m.PausePrivateGame(withKey("2"), 1)
+				m.StartPrivateGame(withKey("1"), 1)
+			},
+			wantRunning: false,
+		},
+		{
+			name: "achievements posted once",
+			calls: func(m *MemoryBackend) {
+				m.PostAchievements(withKey("1"), testAchievements)
+				m.PostAchievements(withKey("1"), testAchievements)
+			},
+			wantAchievements: len(testAchievements),
+		},
+		{
+			name: "calls without a key always applied",
+			calls: func(m *MemoryBackend) {
+				m.StartPrivateGame(context.Background(), 1)
+				m.PausePrivateGame(context.Background(), 1)
+				m.StartPrivateGame(context.Background(), 1)
+				m.PostAchievements(context.Background(), testAchievements)
+				m.PostAchievements(context.Background(), testAchievements)
+			},
+			wantRunning:      true,
+			wantAchievements: 2 * len(testAchievements),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := NewMemoryBackend()
+			test.calls(backend)
+
+			if running := backend.Running(1); running != test.wantRunning {
+				t.Errorf("got running %v, want %v", running, test.wantRunning)
+			}
+			players, _ := backend.GetValues(context.Background(), 1)
+			var ids []string
+			for _, player := range players {
+				ids = append(ids, player.PlayerID)
+			}
+			if !slices.Equal(ids, test.wantPlayers) {
+				t.Errorf("got players %v, want %v", ids, test.wantPlayers)
+			}
+			if achievements := len(backend.Achievements()); achievements != test.wantAchievements {
+				t.Errorf("got %d achievements, want %d", achievements, test.wantAchievements)
+			}
+		})
+	}
+}
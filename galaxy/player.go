@@ -1,30 +1,91 @@
 package galaxy
 
 import (
-	"log"
-	"math/rand"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"galaxy.io/server/config"
+	"galaxy.io/server/logging"
 	pb "galaxy.io/server/proto"
 	"github.com/google/uuid"
 )
 
-const (
-	STARTING_RADIUS = 50
-)
+// Life holds the stats of the current life of a player, added to the
+// session totals of its Log when the player dies or leaves.
+type Life struct {
+	// Inicio de la vida
+	Start time.Time
+	// Radio máximo alcanzado en esta vida
+	PeakRadius uint32
+	// Jugadores eliminados en esta vida
+	Kills uint32
+	// Comidas comidas en esta vida
+	FoodEaten uint32
+	// Distancia recorrida en esta vida
+	DistanceTravelled float64
+}
 
 type Log struct {
 	sync.Mutex
-	// Puntuación obtenida
-	Score uint32
+	// Radio máximo alcanzado, usado como puntuación
+	PeakRadius uint32
 	// Jugadores eliminados
 	KilledPlayers uint32
+	// Comidas comidas
+	FoodEaten uint32
+	// Distancia recorrida
+	DistanceTravelled float64
+	// Mejor racha de eliminaciones en una sola vida
+	LongestKillStreak uint32
+	// Último jugador que lo eliminó
+	KilledBy *uuid.UUID
+	// Vida actual y duración de las anteriores
+	Life  Life
+	Lives []time.Duration
+	// Tiempo en el primer puesto de la clasificación
+	TimeAtTop time.Duration
 	// Segundos jugados
 	TimeStart time.Time
 	TimeEnd   time.Time
 }
 
+// recordKill counts an eliminated player towards the kill streak.
+func (l *Log) recordKill() {
+	l.Life.Kills++
+	l.LongestKillStreak = max(l.LongestKillStreak, l.Life.Kills)
+}
+
+func (l *Log) startLife(at time.Time, radius uint32) {
+	l.Life = Life{
+		Start:      at,
+		PeakRadius: radius,
+	}
+}
+
+// endLife adds the current life, if one was started, to the session
+// totals.
+func (l *Log) endLife(at time.Time) {
+	if l.Life.Start.IsZero() {
+		return
+	}
+	l.PeakRadius = max(l.PeakRadius, l.Life.PeakRadius)
+	l.KilledPlayers += l.Life.Kills
+	l.FoodEaten += l.Life.FoodEaten
+	l.DistanceTravelled += l.Life.DistanceTravelled
+	l.Lives = append(l.Lives, at.Sub(l.Life.Start))
+	l.Life = Life{}
+}
+
+func (l *Log) longestLife() time.Duration {
+	var longest time.Duration
+	for _, life := range l.Lives {
+		longest = max(longest, life)
+	}
+	return longest
+}
+
 // Player represents a unique player in a game.
 type Player struct {
 	sync.RWMutex
@@ -42,44 +103,95 @@ type Player struct {
 
 	Skin *string
 
+	// Address of the client and when it connected, empty for bots.
+	RemoteAddr  string
+	ConnectedAt time.Time
+
+	// End of the spawn protection and where it started, zero when the
+	// player isn't protected.
+	protectedUntil  time.Time
+	protectedOrigin Vector2D
+
+	// Set on connections that joined only to watch, without a cell.
+	watchOnly bool
+	// Player followed while spectating, nil when roaming freely.
+	following uuid.UUID
+	// The camera of the spectator is moved by the director.
+	directed bool
+	// Holds back the events sent to the spectator, nil when they are sent
+	// right away.
+	feed atomic.Pointer[DelayedFeed]
+
 	conn ClientConnection
 }
 
-func NewPlayer(connectionID uuid.UUID, conn ClientConnection) *Player {
+func NewPlayer(connectionID uuid.UUID, conn ClientConnection, rng *Random, world config.WorldConfig) *Player {
 	return &Player{
 		// PlayerID: playerID,
 		ConnectionID: connectionID,
-		Position:     randomPosition(),
-		Radius:       STARTING_RADIUS,
-		Color:        FoodColors[rand.Intn(len(FoodColors))],
+		Position:     randomPosition(rng, world),
+		Radius:       world.StartingRadius,
+		Color:        randomColor(rng),
 		Skin:         nil,
 		conn:         conn,
 		Username:     "UNKNOWN",
-		Stats:        Log{},
 		disconnect:   false,
 	}
 }
 
+// sendErrorSampler limits the logs of failed sends, a full buffer fails
+// every event sent to the player until it drains.
+var sendErrorSampler = logging.NewSampler(100)
+
+// Logger returns a logger carrying the identifiers of the player.
+func (p *Player) Logger() *slog.Logger {
+	return slog.With("connectionID", p.ConnectionID, "playerID", p.PlayerID)
+}
+
+// IsBot reports whether the player is controlled by the server.
+func (p *Player) IsBot() bool {
+	return p.conn == nil
+}
+
 func (p *Player) SendEvent(event *pb.Event) error {
 	if p.conn == nil {
 		return nil
 	}
 	err := p.conn.SendEvent(event)
 	if err != nil {
-		log.Printf("error in sendEvent: %v", err)
+		if sendErrorSampler.Allow() {
+			p.Logger().Warn("error sending event", "err", err)
+		}
 	}
 
 	return err
 }
 
 func (p *Player) Disconnect() {
-	log.Printf("disconnecting player %v", p.PlayerID)
+	p.Logger().Info("disconnecting player")
 	p.disconnect = true
 	if p.conn != nil {
 		p.conn.Close()
 	}
 }
 
+// IsProtected reports whether the player is in its spawn protection.
+func (p *Player) IsProtected() bool {
+	p.RLock()
+	defer p.RUnlock()
+	return !p.protectedUntil.IsZero()
+}
+
+// stopProtection ends the spawn protection, reporting whether the player
+// was protected.
+func (p *Player) stopProtection() bool {
+	p.Lock()
+	defer p.Unlock()
+	protected := !p.protectedUntil.IsZero()
+	p.protectedUntil = time.Time{}
+	return protected
+}
+
 func (p *Player) UpdatePosition(position *Vector2D) {
 	// log.Printf("updating player position, player = %v, oldpos = %v, newpos = %v", p.PlayerID, p.Position, position)
 	p.Lock()
@@ -92,7 +204,7 @@ func (p *Player) UpdatePlayerID(playerID uuid.UUID) {
 }
 
 func (p *Player) UpdateUsername(username string) {
-	log.Printf("updating username for %v to %v", p.ConnectionID, username)
+	p.Logger().Debug("updating username", "username", username)
 	p.Username = username
 }
 
@@ -119,7 +231,7 @@ func (p *Player) UpdateRadius(radius uint32) {
 	p.Radius = radius
 
 	p.Stats.Lock()
-	p.Stats.Score = radius
+	p.Stats.Life.PeakRadius = max(p.Stats.Life.PeakRadius, radius)
 	p.Stats.Unlock()
 
 	p.Unlock()
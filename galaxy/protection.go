@@ -0,0 +1,60 @@
+package galaxy
+
+import pb "galaxy.io/server/proto"
+
+// protect starts the spawn protection of a player that has just joined or
+// respawned. It ends on its own once the configured time has passed.
+func (w *World) protect(player *Player) {
+	duration := w.config.World.SpawnProtection
+	if duration <= 0 {
+		return
+	}
+
+	until := w.clock.Now().Add(duration)
+	player.Lock()
+	player.protectedUntil = until
+	player.protectedOrigin = *player.Position
+	player.Unlock()
+
+	go func() {
+		<-w.clock.After(duration)
+
+		player.RLock()
+		current := player.protectedUntil.Equal(until)
+		player.RUnlock()
+		if current {
+			w.endProtection(player)
+		}
+	}()
+}
+
+// checkProtectionDistance ends the spawn protection of a player that has
+// moved far enough from where it spawned.
+func (w *World) checkProtectionDistance(player *Player) {
+	player.RLock()
+	moved := !player.protectedUntil.IsZero() &&
+		distance(&player.protectedOrigin, player.Position) >= w.config.World.SpawnProtectionDistance
+	player.RUnlock()
+
+	if moved {
+		w.endProtection(player)
+	}
+}
+
+// endProtection ends the spawn protection of a player, if it has one, and
+// tells every client.
+func (w *World) endProtection(player *Player) {
+	if !player.stopProtection() {
+		return
+	}
+
+	w.playerLogger(player).Debug("spawn protection ended")
+	w.broadcastEvent(&pb.Event{
+		EventType: pb.EventType_EvProtectionEnd.Enum(),
+		EventData: &pb.Event_ProtectionEndEvent{
+			ProtectionEndEvent: &pb.ProtectionEndEvent{
+				PlayerID: player.PlayerID[:],
+			},
+		},
+	})
+}
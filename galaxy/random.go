@@ -0,0 +1,57 @@
+package galaxy
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Random is the random source of a world. Every random decision in the
+// galaxy package goes through it, so a world can be reproduced by
+// replaying its seed with the same inputs.
+type Random struct {
+	sync.Mutex
+	rng  *rand.Rand
+	seed uint64
+}
+
+func NewRandom(seed uint64) *Random {
+	return &Random{
+		rng:  rand.New(rand.NewPCG(seed, seed)),
+		seed: seed,
+	}
+}
+
+// Seed returns the seed the source was created with.
+func (r *Random) Seed() uint64 {
+	return r.seed
+}
+
+func (r *Random) Uint32N(n uint32) uint32 {
+	r.Lock()
+	defer r.Unlock()
+	return r.rng.Uint32N(n)
+}
+
+func (r *Random) IntN(n int) int {
+	r.Lock()
+	defer r.Unlock()
+	return r.rng.IntN(n)
+}
+
+// Read fills p with random bytes, it never fails.
+func (r *Random) Read(p []byte) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+	for i := range p {
+		p[i] = byte(r.rng.Uint32())
+	}
+	return len(p), nil
+}
+
+// UUID returns a version 4 UUID drawn from the source.
+func (r *Random) UUID() uuid.UUID {
+	id, _ := uuid.NewRandomFromReader(r)
+	return id
+}
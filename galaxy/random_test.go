@@ -0,0 +1,76 @@
+package galaxy
+
+import (
+	"slices"
+	"testing"
+
+	"galaxy.io/server/config"
+)
+
+// seeded is what a world draws from its random source.
+type seeded struct {
+	food     []Food
+	botNames []string
+	spawns   []Vector2D
+}
+
+func drawSeeded(t *testing.T, seed uint64) seeded {
+	t.Helper()
+	rng := NewRandom(seed)
+	w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.World.Food = 0
+	})
+	w.rng = rng
+
+	var drawn seeded
+	drawn.food = createRandomFood(rng, config.Default().World)
+	w.food = drawn.food
+	for range 3 {
+		drawn.botNames = append(drawn.botNames, NewBot(w.config, clock, rng).player.Username)
+	}
+	for range 3 {
+		drawn.spawns = append(drawn.spawns, *w.spawnPosition(w.config.World.StartingRadius))
+	}
+	return drawn
+}
+
+func TestRandomSeed(t *testing.T) {
+	tests := []struct {
+		name      string
+		seeds     [2]uint64
+		wantEqual bool
+	}{
+		{"same seed", [2]uint64{42, 42}, true},
+		{"different seeds", [2]uint64{42, 43}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first := drawSeeded(t, test.seeds[0])
+			second := drawSeeded(t, test.seeds[1])
+
+			if equal := slices.Equal(first.food, second.food); equal != test.wantEqual {
+				t.Errorf("got the same food %v, want %v", equal, test.wantEqual)
+			}
+			if equal := slices.Equal(first.botNames, second.botNames); equal != test.wantEqual {
+				t.Errorf("got bots %v and %v, want the same %v", first.botNames, second.botNames, test.wantEqual)
+			}
+			if equal := slices.Equal(first.spawns, second.spawns); equal != test.wantEqual {
+				t.Errorf("got spawns %v and %v, want the same %v", first.spawns, second.spawns, test.wantEqual)
+			}
+		})
+	}
+}
+
+// A world started from the seed of another one has the same food.
+func TestWorldSeed(t *testing.T) {
+	first, _, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.World.Seed = 7
+	})
+	second, _, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.World.Seed = first.rng.Seed()
+	})
+	if !slices.Equal(first.food, second.food) {
+		t.Errorf("worlds of seed %d got different food", first.rng.Seed())
+	}
+}
@@ -0,0 +1,277 @@
+package galaxy
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"galaxy.io/server/logging"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	REPLAY_EXTENSION = ".replay"
+
+	defaultRecordRotation = time.Hour
+	defaultRecordMaxSize  = 64 << 20
+	recordQueueSize       = 4096
+)
+
+var droppedRecordSampler = logging.NewSampler(1000)
+
+type RecorderConfig struct {
+	// Directory where replay files are written.
+	Dir string
+	// A new file is started after this much time.
+	RotateEvery time.Duration
+	// A new file is started once the current one grows past this size.
+	MaxFileSize int64
+}
+
+// Recorder writes every operation received and every event broadcast by a
+// world to length-delimited protobuf files, so matches can be replayed.
+// Each file starts with a header and a snapshot of the world, which makes
+// every file playable on its own.
+//
+// All methods are safe to call on a nil Recorder, which records nothing.
+type Recorder struct {
+	config RecorderConfig
+	world  *World
+	// Guards records against being closed while a record is enqueued.
+	closeMutex sync.RWMutex
+	closed     bool
+	records    chan *pb.ReplayRecord
+	rotate     chan struct{}
+	done       chan struct{}
+
+	file    *os.File
+	writer  *bufio.Writer
+	written int64
+	opened  time.Time
+	// Records queued before the snapshot of the current file are already
+	// part of it and are skipped.
+	since int64
+}
+
+func NewRecorder(config RecorderConfig, world *World) (*Recorder, error) {
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	if config.RotateEvery <= 0 {
+		config.RotateEvery = defaultRecordRotation
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = defaultRecordMaxSize
+	}
+
+	r := &Recorder{
+		config:  config,
+		world:   world,
+		records: make(chan *pb.ReplayRecord, recordQueueSize),
+		rotate:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r, nil
+}
+
+// RecordOperation records an operation received from a connection.
+func (r *Recorder) RecordOperation(connectionID uuid.UUID, operation *pb.Operation) {
+	if r == nil {
+		return
+	}
+
+	r.enqueue(&pb.ReplayRecord{
+		RecordData: &pb.ReplayRecord_Operation{
+			Operation: &pb.ReplayOperation{
+				ConnectionID: connectionID[:],
+				Operation:    operation,
+			},
+		},
+	})
+}
+
+// RecordEvent records a copy of an event broadcast to every player, the
+// original may point to fields of players that change before it is written.
+func (r *Recorder) RecordEvent(event *pb.Event) {
+	if r == nil {
+		return
+	}
+
+	r.enqueue(&pb.ReplayRecord{
+		RecordData: &pb.ReplayRecord_Event{
+			Event: proto.Clone(event).(*pb.Event),
+		},
+	})
+}
+
+// Rotate closes the current file and starts a new one, it is called when
+// a match starts or ends.
+func (r *Recorder) Rotate() {
+	if r == nil {
+		return
+	}
+
+	select {
+	case r.rotate <- struct{}{}:
+	default:
+		// a rotation is already pending
+	}
+}
+
+// Close flushes every queued record and closes the current file.
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+
+	r.closeMutex.Lock()
+	if r.closed {
+		r.closeMutex.Unlock()
+		return
+	}
+	r.closed = true
+	close(r.records)
+	r.closeMutex.Unlock()
+
+	<-r.done
+}
+
+func (r *Recorder) enqueue(record *pb.ReplayRecord) {
+	timestamp := r.world.clock.Now().UnixNano()
+	record.Timestamp = &timestamp
+
+	r.closeMutex.RLock()
+	defer r.closeMutex.RUnlock()
+	if r.closed {
+		return
+	}
+
+	select {
+	case r.records <- record:
+	default:
+		if droppedRecordSampler.Allow() {
+			slog.Warn("replay queue full, dropping record")
+		}
+	}
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+	defer r.closeFile()
+
+	if err := r.openFile(); err != nil {
+		slog.Error("error opening replay file", "err", err)
+	}
+
+	for {
+		select {
+		case record, ok := <-r.records:
+			if !ok {
+				return
+			}
+			if r.file == nil || r.shouldRotate() {
+				if err := r.openFile(); err != nil {
+					slog.Error("error opening replay file", "err", err)
+					continue
+				}
+			}
+			if record.GetTimestamp() < r.since {
+				continue
+			}
+			if err := r.write(record); err != nil {
+				slog.Error("error writing replay record", "err", err)
+			}
+
+		case <-r.rotate:
+			if err := r.openFile(); err != nil {
+				slog.Error("error opening replay file", "err", err)
+			}
+		}
+	}
+}
+
+func (r *Recorder) shouldRotate() bool {
+	if r.written >= r.config.MaxFileSize {
+		return true
+	}
+	return r.world.clock.Now().Sub(r.opened) >= r.config.RotateEvery
+}
+
+func (r *Recorder) openFile() error {
+	r.closeFile()
+
+	now := r.world.clock.Now()
+	header := r.world.replayHeader(now)
+
+	name := "galaxy-" + now.UTC().Format("20060102-150405.000")
+	if header.GameID != nil {
+		name += fmt.Sprintf("-game%v", *header.GameID)
+	}
+	path := filepath.Join(r.config.Dir, name+REPLAY_EXTENSION)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("recording match", "path", path)
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.written = 0
+	r.opened = now
+	r.since = now.UnixNano()
+
+	timestamp := now.UnixNano()
+	err = r.write(&pb.ReplayRecord{
+		Timestamp:  &timestamp,
+		RecordData: &pb.ReplayRecord_Header{Header: header},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range r.world.snapshotEvents() {
+		err = r.write(&pb.ReplayRecord{
+			Timestamp:  &timestamp,
+			RecordData: &pb.ReplayRecord_Event{Event: event},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) write(record *pb.ReplayRecord) error {
+	n, err := protodelim.MarshalTo(r.writer, record)
+	r.written += int64(n)
+	return err
+}
+
+func (r *Recorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+
+	if err := r.writer.Flush(); err != nil {
+		slog.Error("error flushing replay file", "err", err)
+	}
+	if err := r.file.Close(); err != nil {
+		slog.Error("error closing replay file", "err", err)
+	}
+
+	r.file = nil
+	r.writer = nil
+}
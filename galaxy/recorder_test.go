@@ -0,0 +1,137 @@
+package galaxy
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+// replayFiles returns the replay files in dir, oldest first.
+func replayFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+REPLAY_EXTENSION))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// waitForFiles waits for the recorder to open its files, which it does
+// without sleeping on the clock.
+func waitForFiles(t *testing.T, dir string, want int) {
+	t.Helper()
+	for start := time.Now(); len(replayFiles(t, dir)) < want; time.Sleep(time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatalf("still %d replay files after a second, want %d", len(replayFiles(t, dir)), want)
+		}
+	}
+}
+
+func TestRecorderReplay(t *testing.T) {
+	w, _, _ := newTestWorld(t, nil)
+	dir := t.TempDir()
+	recorder, err := NewRecorder(RecorderConfig{Dir: dir}, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := NewPlayer(uuid.New(), newTestConnection(), w.rng, w.config.World)
+	player.UpdateRadius(50)
+	recorder.RecordEvent(&pb.Event{
+		EventType: pb.EventType_EvNewPlayer.Enum(),
+		EventData: &pb.Event_NewPlayerEvent{
+			NewPlayerEvent: &pb.NewPlayerEvent{
+				PlayerID: player.PlayerID[:],
+				Radius:   &player.Radius,
+			},
+		},
+	})
+	// the event was broadcast with the radius it had then
+	player.UpdateRadius(200)
+	recorder.RecordOperation(player.ConnectionID, &pb.Operation{OperationType: pb.OperationType_OpRespawn.Enum()})
+	recorder.RecordEvent(&pb.Event{EventType: pb.EventType_EvPause.Enum()})
+	recorder.Close()
+
+	paths := replayFiles(t, dir)
+	if len(paths) != 1 {
+		t.Fatalf("got %d replay files, want 1", len(paths))
+	}
+	replay, err := LoadReplay(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seed := replay.Header.GetSeed(); seed != w.rng.Seed() {
+		t.Errorf("got seed %d, want %d", seed, w.rng.Seed())
+	}
+	if start := replay.Start(); start != w.clock.Now().UnixNano() {
+		t.Errorf("got start %d, want %d", start, w.clock.Now().UnixNano())
+	}
+
+	var newPlayers []*pb.NewPlayerEvent
+	for _, record := range replay.Events {
+		if event := record.GetEvent().GetNewPlayerEvent(); event != nil {
+			newPlayers = append(newPlayers, event)
+		}
+	}
+	if len(newPlayers) != 1 || newPlayers[0].GetRadius() != 50 {
+		t.Errorf("got new players %v, want one with a radius of 50", newPlayers)
+	}
+	if last := replay.Events[len(replay.Events)-1].GetEvent(); last.GetEventType() != pb.EventType_EvPause {
+		t.Errorf("got %v last, want the pause", last.GetEventType())
+	}
+}
+
+func TestRecorderRotation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RecorderConfig
+		// Time between every event recorded.
+		step time.Duration
+		// Number of files after every event.
+		wantFiles []int
+	}{
+		{"by size", RecorderConfig{MaxFileSize: 1}, time.Millisecond, []int{2, 3, 4}},
+		{"by time", RecorderConfig{RotateEvery: time.Minute}, 40 * time.Second, []int{1, 2, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, nil)
+			test.config.Dir = t.TempDir()
+			recorder, err := NewRecorder(test.config, w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			waitForFiles(t, test.config.Dir, 1)
+
+			for _, want := range test.wantFiles {
+				clock.Advance(test.step)
+				recorder.RecordEvent(&pb.Event{EventType: pb.EventType_EvPause.Enum()})
+				waitForFiles(t, test.config.Dir, want)
+			}
+			recorder.Close()
+
+			paths := replayFiles(t, test.config.Dir)
+			if want := test.wantFiles[len(test.wantFiles)-1]; len(paths) != want {
+				t.Fatalf("got %d replay files, want %d", len(paths), want)
+			}
+			for _, path := range paths {
+				if _, err := LoadReplay(path); err != nil {
+					t.Errorf("loading %v: %v", filepath.Base(path), err)
+				}
+			}
+			last, err := LoadReplay(paths[len(paths)-1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if end := last.End(); end != clock.Now().UnixNano() {
+				t.Errorf("last file ends at %d, want %d", end, clock.Now().UnixNano())
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package galaxy
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	REPLAY_MIN_SPEED = 0.5
+	REPLAY_MAX_SPEED = 8
+
+	replayTick = 20 * time.Millisecond
+)
+
+// Replay is a recorded match loaded in memory.
+type Replay struct {
+	Header *pb.ReplayHeader
+	// Events in the order they were broadcast, including the snapshot at
+	// the start of the file.
+	Events []*pb.ReplayRecord
+}
+
+func LoadReplay(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	replay := &Replay{}
+
+	for {
+		record := &pb.ReplayRecord{}
+		err := protodelim.UnmarshalFrom(reader, record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case record.GetHeader() != nil:
+			if replay.Header == nil {
+				replay.Header = record.GetHeader()
+			}
+		case record.GetEvent() != nil:
+			replay.Events = append(replay.Events, record)
+		}
+	}
+
+	if replay.Header == nil {
+		return nil, fmt.Errorf("replay %v has no header", path)
+	}
+
+	return replay, nil
+}
+
+// Start returns the unix time in nanoseconds the replay starts at.
+func (r *Replay) Start() int64 {
+	return r.Header.GetStartTime()
+}
+
+// End returns the unix time in nanoseconds of the last event.
+func (r *Replay) End() int64 {
+	if len(r.Events) == 0 {
+		return r.Start()
+	}
+	return r.Events[len(r.Events)-1].GetTimestamp()
+}
+
+// ReplayServer serves a replay over the same protocol as a World. Every
+// connection watches the match on its own and can change the speed, seek
+// and pause with ReplayControlOperation. Any other operation is ignored,
+// as the world is read only.
+type ReplayServer struct {
+	replay            *Replay
+	connectionFactory ConnectionFactory
+	clock             Clock
+}
+
+func NewReplayServer(replay *Replay, factory ConnectionFactory, clock Clock) *ReplayServer {
+	return &ReplayServer{
+		replay:            replay,
+		connectionFactory: factory,
+		clock:             clock,
+	}
+}
+
+// HandleNewConnection starts a viewer for the connection. The initial speed
+// and position can be set with the speed and start query parameters, for
+// example ?speed=2&start=1m30s.
+func (s *ReplayServer) HandleNewConnection(writer http.ResponseWriter, r *http.Request) {
+	connectionID := uuid.New()
+	slog.Info("handling new replay viewer", "connectionID", connectionID)
+
+	viewer := &replayViewer{
+		id:     connectionID,
+		replay: s.replay,
+		clock:  s.clock,
+		speed:  1,
+		state:  newReplayState(),
+	}
+
+	if value := r.URL.Query().Get("speed"); value != "" {
+		speed, err := strconv.ParseFloat(value, 32)
+		if err == nil {
+			viewer.speed = clampSpeed(speed)
+		}
+	}
+
+	if value := r.URL.Query().Get("start"); value != "" {
+		start, err := time.ParseDuration(value)
+		if err == nil {
+			viewer.startOffset = start
+		}
+	}
+
+	conn, err := s.connectionFactory.NewConnection(writer, r, viewer.handleOperation)
+	if err != nil {
+		slog.Error("error creating connection", "connectionID", connectionID, "err", err)
+		return
+	}
+
+	viewer.Lock()
+	viewer.conn = conn
+	if viewer.pendingJoin {
+		viewer.start()
+	}
+	viewer.Unlock()
+}
+
+type replayViewer struct {
+	sync.Mutex
+	id     uuid.UUID
+	conn   ClientConnection
+	replay *Replay
+	clock  Clock
+
+	started     bool
+	pendingJoin bool
+	closed      bool
+	startOffset time.Duration
+	speed       float64
+	paused      bool
+	// Replay time in unix nanoseconds.
+	position int64
+	// Index of the next event to send.
+	index int
+	// What the viewer currently has on screen, used to clear it on seek.
+	state *replayState
+}
+
+func (v *replayViewer) handleOperation(operation *pb.Operation) {
+	switch operation.GetOperationType() {
+	case pb.OperationType_OpJoin:
+		v.Lock()
+		v.start()
+		v.Unlock()
+	case pb.OperationType_OpReplayControl:
+		v.control(operation.GetReplayControlOperation())
+	case pb.OperationType_OpLeave:
+		v.close()
+	default:
+		// the replay is read only
+	}
+}
+
+// start sends the viewer the state of the match and starts playing it.
+// The viewer must be locked.
+func (v *replayViewer) start() {
+	if v.conn == nil {
+		// the join arrived before the connection was set up
+		v.pendingJoin = true
+		return
+	}
+	if v.started {
+		return
+	}
+	v.started = true
+
+	center := &Vector2D{
+		X: v.replay.Header.GetWorldWidth() / 2,
+		Y: v.replay.Header.GetWorldHeight() / 2,
+	}
+	joinEvent := &pb.Event{
+		EventType: pb.EventType_EvJoin.Enum(),
+		EventData: &pb.Event_JoinEvent{
+			JoinEvent: &pb.JoinEvent{
+				PlayerID: v.id[:],
+				Position: center.toPacket(),
+				Radius:   proto.Uint32(0),
+				Color:    proto.Uint32(0),
+			},
+		},
+	}
+	if !v.send(joinEvent) {
+		return
+	}
+
+	v.seek(v.replay.Start() + v.startOffset.Nanoseconds())
+
+	go v.play()
+}
+
+func (v *replayViewer) control(operation *pb.ReplayControlOperation) {
+	if operation == nil {
+		return
+	}
+
+	v.Lock()
+	defer v.Unlock()
+
+	if operation.Speed != nil {
+		v.speed = clampSpeed(float64(operation.GetSpeed()))
+	}
+	if operation.Paused != nil {
+		v.paused = operation.GetPaused()
+	}
+	if operation.Seek != nil && v.started {
+		v.seek(v.replay.Start() + operation.GetSeek()*int64(time.Millisecond))
+	}
+}
+
+func (v *replayViewer) close() {
+	v.Lock()
+	defer v.Unlock()
+
+	v.closed = true
+	if v.conn != nil {
+		v.conn.Close()
+	}
+}
+
+// play sends the events of the match as they come due, until the viewer
+// leaves or its connection is closed.
+func (v *replayViewer) play() {
+	for {
+		select {
+		case <-v.clock.After(replayTick):
+		case <-v.conn.Done():
+			slog.Info("replay viewer disconnected", "connectionID", v.id)
+			v.close()
+			return
+		}
+
+		v.Lock()
+		if v.closed {
+			v.Unlock()
+			return
+		}
+
+		if !v.paused && v.position < v.replay.End() {
+			v.position += int64(float64(replayTick) * v.speed)
+		}
+
+		for v.index < len(v.replay.Events) && v.replay.Events[v.index].GetTimestamp() <= v.position {
+			event := v.replay.Events[v.index].GetEvent()
+			v.index++
+			v.state.apply(event)
+			if !v.send(event) {
+				break
+			}
+		}
+		v.Unlock()
+	}
+}
+
+// seek clears what the viewer has on screen and sends the state of the
+// match at the given time. The viewer must be locked.
+func (v *replayViewer) seek(position int64) {
+	position = max(v.replay.Start(), min(position, v.replay.End()))
+
+	for _, event := range v.state.clearEvents() {
+		if !v.send(event) {
+			return
+		}
+	}
+
+	v.state = newReplayState()
+	v.index = 0
+	for v.index < len(v.replay.Events) && v.replay.Events[v.index].GetTimestamp() <= position {
+		v.state.apply(v.replay.Events[v.index].GetEvent())
+		v.index++
+	}
+	v.position = position
+
+	for _, event := range v.state.snapshotEvents() {
+		if !v.send(event) {
+			return
+		}
+	}
+}
+
+// send sends an event to the viewer, closing it on error. The viewer must
+// be locked.
+func (v *replayViewer) send(event *pb.Event) bool {
+	if v.closed {
+		return false
+	}
+
+	err := v.conn.SendEvent(event)
+	if err != nil {
+		slog.Info("closing replay viewer", "connectionID", v.id, "err", err)
+		v.closed = true
+		v.conn.Close()
+		return false
+	}
+	return true
+}
+
+func clampSpeed(speed float64) float64 {
+	return max(REPLAY_MIN_SPEED, min(speed, REPLAY_MAX_SPEED))
+}
+
+// replayState rebuilds the world from the events of a replay.
+type replayState struct {
+	players map[string]*pb.NewPlayerEvent
+	food    map[Vector2D]*pb.Food
+}
+
+func newReplayState() *replayState {
+	return &replayState{
+		players: make(map[string]*pb.NewPlayerEvent),
+		food:    make(map[Vector2D]*pb.Food),
+	}
+}
+
+func (s *replayState) apply(event *pb.Event) {
+	switch data := event.GetEventData().(type) {
+	case *pb.Event_NewPlayerEvent:
+		s.players[string(data.NewPlayerEvent.GetPlayerID())] = proto.Clone(data.NewPlayerEvent).(*pb.NewPlayerEvent)
+	case *pb.Event_PlayerMoveEvent:
+		if player, exists := s.players[string(data.PlayerMoveEvent.GetPlayerID())]; exists {
+			player.Position = data.PlayerMoveEvent.GetPosition()
+		}
+	case *pb.Event_PlayerGrowEvent:
+		if player, exists := s.players[string(data.PlayerGrowEvent.GetPlayerID())]; exists {
+			player.Radius = proto.Uint32(data.PlayerGrowEvent.GetRadius())
+		}
+	case *pb.Event_DestroyPlayerEvent:
+		delete(s.players, string(data.DestroyPlayerEvent.GetPlayerID()))
+	case *pb.Event_NewFoodEvent:
+		for _, food := range data.NewFoodEvent.GetFood() {
+			s.food[*VectorFromPacket(food.GetPosition())] = food
+		}
+	case *pb.Event_DestroyFoodEvent:
+		delete(s.food, *VectorFromPacket(data.DestroyFoodEvent.GetPosition()))
+	}
+}
+
+func (s *replayState) snapshotEvents() []*pb.Event {
+	var events []*pb.Event
+
+	for _, player := range s.players {
+		events = append(events, &pb.Event{
+			EventType: pb.EventType_EvNewPlayer.Enum(),
+			EventData: &pb.Event_NewPlayerEvent{
+				NewPlayerEvent: player,
+			},
+		})
+	}
+
+	var pbFoods []*pb.Food
+	for _, food := range s.food {
+		pbFoods = append(pbFoods, food)
+	}
+	events = append(events, &pb.Event{
+		EventType: pb.EventType_EvNewFood.Enum(),
+		EventData: &pb.Event_NewFoodEvent{
+			NewFoodEvent: &pb.NewFoodEvent{
+				Food: pbFoods,
+			},
+		},
+	})
+
+	return events
+}
+
+func (s *replayState) clearEvents() []*pb.Event {
+	var events []*pb.Event
+
+	for playerID := range s.players {
+		events = append(events, &pb.Event{
+			EventType: pb.EventType_EvDestroyPlayer.Enum(),
+			EventData: &pb.Event_DestroyPlayerEvent{
+				DestroyPlayerEvent: &pb.DestroyPlayerEvent{
+					PlayerID: []byte(playerID),
+				},
+			},
+		})
+	}
+
+	for position := range s.food {
+		events = append(events, &pb.Event{
+			EventType: pb.EventType_EvDestroyFood.Enum(),
+			EventData: &pb.Event_DestroyFoodEvent{
+				DestroyFoodEvent: &pb.DestroyFoodEvent{
+					Position: position.toPacket(),
+				},
+			},
+		})
+	}
+
+	return events
+}
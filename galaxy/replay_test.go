@@ -0,0 +1,60 @@
+package galaxy
+
+import (
+	"testing"
+	"time"
+
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReplayViewerStopsOnDisconnect(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		paused bool
+		// How far the viewer watched before disconnecting.
+		watched time.Duration
+	}{
+		{"while playing", false, 100 * time.Millisecond},
+		{"while paused", true, 100 * time.Millisecond},
+		{"after the end", false, 10 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			conn := newTestConnection()
+			viewer := &replayViewer{
+				id:   uuid.New(),
+				conn: conn,
+				replay: &Replay{
+					Header: &pb.ReplayHeader{StartTime: proto.Int64(start.UnixNano())},
+					Events: []*pb.ReplayRecord{{
+						Timestamp: proto.Int64(start.Add(time.Second).UnixNano()),
+						RecordData: &pb.ReplayRecord_Event{
+							Event: &pb.Event{EventType: pb.EventType_EvPause.Enum()},
+						},
+					}},
+				},
+				clock:  clock,
+				speed:  1,
+				paused: test.paused,
+				state:  newReplayState(),
+			}
+
+			viewer.Lock()
+			viewer.start()
+			viewer.Unlock()
+			clock.Advance(test.watched)
+
+			conn.Close()
+			advanceUntil(t, clock, replayTick, time.Second, func() bool {
+				viewer.Lock()
+				defer viewer.Unlock()
+				return viewer.closed
+			})
+		})
+	}
+}
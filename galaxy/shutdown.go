@@ -0,0 +1,90 @@
+package galaxy
+
+import (
+	"context"
+	"log/slog"
+
+	pb "galaxy.io/server/proto"
+)
+
+// Shutdown stops the world: it tells every client the server is going
+// down, saves the running private game, posts the achievements of every
+// human, closes every connection and waits for the outbox to deliver the
+// queued backend calls and achievements. It gives up once ctx is done,
+// leaving what is queued in the outbox directory for the next start.
+func (w *World) Shutdown(ctx context.Context, reason string) error {
+	if w.shuttingDown.Swap(true) {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.shutdown(reason)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("world shut down")
+		return w.outbox.Close(ctx)
+	case <-ctx.Done():
+		slog.Error("world shutdown did not finish in time", "err", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+func (w *World) shutdown(reason string) {
+	slog.Info("shutting down world", "reason", reason)
+
+	w.broadcastEvent(&pb.Event{
+		EventType: pb.EventType_EvShutdown.Enum(),
+		EventData: &pb.Event_ShutdownEvent{
+			ShutdownEvent: &pb.ShutdownEvent{
+				Reason: &reason,
+			},
+		},
+	})
+
+	w.playersMutex.Lock()
+	if gameID := w.gameID; gameID != nil {
+		slog.Info("saving private game before shutting down", "gameID", *gameID)
+		w.savePrivateGame(*gameID)
+	}
+
+	players := make([]*Player, 0, len(w.players)+len(w.spectators))
+	for id, player := range w.players {
+		players = append(players, player)
+		delete(w.players, id)
+	}
+	for id, spectator := range w.spectators {
+		players = append(players, spectator)
+		delete(w.spectators, id)
+		w.undelay(spectator)
+	}
+	connections := make([]*Player, 0, len(w.playersConnection))
+	for _, player := range w.playersConnection {
+		connections = append(connections, player)
+	}
+	w.playersMutex.Unlock()
+
+	now := w.clock.Now()
+	for _, player := range players {
+		if player.IsBot() || player.watchOnly {
+			continue
+		}
+		player.Stats.Lock()
+		player.Stats.TimeEnd = now
+		player.Stats.endLife(now)
+		player.Stats.Unlock()
+		w.postAchievements(player)
+	}
+
+	for _, player := range players {
+		player.Disconnect()
+	}
+	for _, player := range connections {
+		player.Disconnect()
+	}
+
+	w.recorder.Close()
+}
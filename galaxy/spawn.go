@@ -0,0 +1,86 @@
+package galaxy
+
+import "math"
+
+const (
+	// SPAWN_CANDIDATES is how many random points are considered for every
+	// spawn.
+	SPAWN_CANDIDATES = 20
+	// SPAWN_SAFE_DISTANCE is the room a spawn point needs between it and
+	// the edge of any larger player to count as safe.
+	SPAWN_SAFE_DISTANCE = 600
+	// SPAWN_FOOD_RANGE is how far around a spawn point food is counted.
+	SPAWN_FOOD_RANGE = 500
+)
+
+type spawnCandidate struct {
+	position *Vector2D
+	// Distance to the edge of the closest larger player.
+	clearance float64
+	// Food within SPAWN_FOOD_RANGE.
+	food int
+}
+
+func (c spawnCandidate) safe() bool {
+	return c.clearance >= SPAWN_SAFE_DISTANCE
+}
+
+// betterThan prefers safe points, then the one with more food around it.
+// When no point is safe, the one furthest from danger wins.
+func (c spawnCandidate) betterThan(other spawnCandidate) bool {
+	if c.safe() != other.safe() {
+		return c.safe()
+	}
+	if c.safe() {
+		return c.food > other.food
+	}
+	return c.clearance > other.clearance
+}
+
+// spawnPosition picks where a player of the given radius enters the world,
+// sampling random points and keeping the one furthest from larger players
+// and with the most food around. It must not be called with the players
+// lock held.
+func (w *World) spawnPosition(radius uint32) *Vector2D {
+	var best spawnCandidate
+	for i := range SPAWN_CANDIDATES {
+		candidate := w.spawnCandidate(randomPosition(w.rng, w.config.World), radius)
+		if i == 0 || candidate.betterThan(best) {
+			best = candidate
+		}
+	}
+	return best.position
+}
+
+// isSafeSpawn reports whether a player of the given radius can enter the
+// world at position without being next to a larger player.
+func (w *World) isSafeSpawn(position *Vector2D, radius uint32) bool {
+	return w.spawnCandidate(position, radius).safe()
+}
+
+func (w *World) spawnCandidate(position *Vector2D, radius uint32) spawnCandidate {
+	candidate := spawnCandidate{
+		position:  position,
+		clearance: math.Inf(1),
+	}
+
+	w.playersMutex.RLock()
+	for _, player := range w.players {
+		if player.Radius <= radius {
+			continue
+		}
+		clearance := float64(distance(position, player.GetPosition())) - float64(player.Radius)
+		candidate.clearance = min(candidate.clearance, clearance)
+	}
+	w.playersMutex.RUnlock()
+
+	w.foodMutex.RLock()
+	for _, food := range w.food {
+		if distance(position, &food.position) < SPAWN_FOOD_RANGE {
+			candidate.food++
+		}
+	}
+	w.foodMutex.RUnlock()
+
+	return candidate
+}
@@ -0,0 +1,107 @@
+package galaxy
+
+import (
+	"testing"
+
+	"galaxy.io/server/config"
+)
+
+func TestSpawnCandidate(t *testing.T) {
+	w, _, _ := newTestWorld(t, func(cfg *config.Config) {
+		cfg.World.Food = 0
+	})
+	addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 200)
+	addTestPlayer(w, Vector2D{X: 3000, Y: 3000}, 20)
+	w.food = []Food{
+		{position: Vector2D{X: 5000, Y: 5000}},
+		{position: Vector2D{X: 5100, Y: 5000}},
+		{position: Vector2D{X: 6000, Y: 6000}},
+	}
+
+	tests := []struct {
+		name     string
+		position Vector2D
+		radius   uint32
+		wantSafe bool
+		wantFood int
+	}{
+		{"next to a larger player", Vector2D{X: 1000, Y: 1500}, 50, false, 0},
+		{"far from larger players", Vector2D{X: 5000, Y: 5100}, 50, true, 2},
+		{"next to a smaller player", Vector2D{X: 3000, Y: 3100}, 50, true, 0},
+		{"larger than everyone", Vector2D{X: 1000, Y: 1500}, 300, true, 0},
+		{"next to a larger player too small to eat it", Vector2D{X: 3000, Y: 3100}, 10, false, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			candidate := w.spawnCandidate(&test.position, test.radius)
+			if candidate.safe() != test.wantSafe {
+				t.Errorf("got safe %v with a clearance of %v, want %v", candidate.safe(), candidate.clearance, test.wantSafe)
+			}
+			if candidate.food != test.wantFood {
+				t.Errorf("got %d food around, want %d", candidate.food, test.wantFood)
+			}
+		})
+	}
+}
+
+func TestSpawnCandidateBetterThan(t *testing.T) {
+	tests := []struct {
+		name  string
+		c     spawnCandidate
+		other spawnCandidate
+		want  bool
+	}{
+		{"safe over unsafe", spawnCandidate{clearance: SPAWN_SAFE_DISTANCE}, spawnCandidate{clearance: 100, food: 10}, true},
+		{"unsafe under safe", spawnCandidate{clearance: 100, food: 10}, spawnCandidate{clearance: SPAWN_SAFE_DISTANCE}, false},
+		{"more food when both are safe", spawnCandidate{clearance: 700, food: 3}, spawnCandidate{clearance: 5000, food: 2}, true},
+		{"less food when both are safe", spawnCandidate{clearance: 5000, food: 2}, spawnCandidate{clearance: 700, food: 3}, false},
+		{"further when both are unsafe", spawnCandidate{clearance: 300}, spawnCandidate{clearance: 200, food: 5}, true},
+		{"closer when both are unsafe", spawnCandidate{clearance: 200, food: 5}, spawnCandidate{clearance: 300}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.c.betterThan(test.other); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSpawnPosition(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  uint32
+		height uint32
+		// A larger player sitting in the corner of the world.
+		radius uint32
+		// Whether some of the world is safe to spawn in.
+		wantSafe bool
+	}{
+		{"away from a larger player", 4000, 1000, 500, true},
+		{"as far as possible when nowhere is safe", 400, 400, 300, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, _, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.Width = test.width
+				cfg.World.Height = test.height
+				cfg.World.Food = 0
+			})
+			addTestPlayer(w, Vector2D{X: 0, Y: 0}, test.radius)
+
+			for range 10 {
+				position := w.spawnPosition(w.config.World.StartingRadius)
+				candidate := w.spawnCandidate(position, w.config.World.StartingRadius)
+				if candidate.safe() != test.wantSafe {
+					t.Errorf("spawned at %+v with a clearance of %v, want safe %v", *position, candidate.clearance, test.wantSafe)
+				}
+				if candidate.clearance <= 0 {
+					t.Errorf("spawned at %+v inside the larger player", *position)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package galaxy
+
+import (
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+)
+
+// operationSpectate lets a connection that hasn't joined watch the world
+// without a cell, and lets spectators choose who to follow.
+func (w *World) operationSpectate(player *Player, operation *pb.SpectateOperation) {
+	logger := w.operationLogger(player, pb.OperationType_OpSpectate)
+	if operation == nil {
+		logger.Warn("nil operation in spectate")
+		return
+	}
+
+	if w.privateServer && operation.GameID != nil && (w.gameID == nil || *w.gameID != *operation.GameID) {
+		logger.Warn("tried spectating a private game that isn't running, kicking him", "gameID", *operation.GameID)
+		player.Disconnect()
+		return
+	}
+
+	w.playersMutex.Lock()
+	if _, playing := w.players[player.PlayerID]; playing {
+		w.playersMutex.Unlock()
+		logger.Warn("tried spectating while playing")
+		return
+	}
+	_, spectating := w.spectators[player.ConnectionID]
+	if !spectating && len(w.spectators)-w.eatenSpectators() >= w.config.Spectators.Max {
+		w.playersMutex.Unlock()
+		logger.Warn("tried spectating with too many spectators, kicking him")
+		player.Disconnect()
+		return
+	}
+	if !spectating {
+		player.watchOnly = true
+		w.delaySpectator(player)
+		w.spectators[player.ConnectionID] = player
+	}
+	w.playersMutex.Unlock()
+
+	if !spectating {
+		logger.Info("connection started spectating")
+		w.sendState(player)
+	}
+
+	if operation.GetDirector() {
+		w.direct(player)
+		return
+	}
+
+	var follow uuid.UUID
+	if operation.Follow != nil {
+		followID, err := uuid.FromBytes(operation.Follow)
+		if err != nil {
+			logger.Warn("unable to parse the player to follow", "err", err)
+		} else if w.isPlaying(followID) {
+			follow = followID
+		}
+	}
+	w.follow(player, follow)
+}
+
+// follow makes a spectator follow a player, or roam freely when playerID is
+// nil, and tells it.
+func (w *World) follow(spectator *Player, playerID uuid.UUID) {
+	spectator.Lock()
+	spectator.following = playerID
+	spectator.directed = false
+	spectator.Unlock()
+
+	spectate := &pb.SpectateEvent{}
+	if playerID != uuid.Nil {
+		spectate.Following = playerID[:]
+	}
+	w.sendEvent(spectator, &pb.Event{
+		EventType: pb.EventType_EvSpectate.Enum(),
+		EventData: &pb.Event_SpectateEvent{
+			SpectateEvent: spectate,
+		},
+	})
+}
+
+// unfollow sets free every spectator following a player that is no longer
+// in the world.
+func (w *World) unfollow(player *Player) {
+	w.playersMutex.RLock()
+	var followers []*Player
+	for _, spectator := range w.spectators {
+		spectator.RLock()
+		if spectator.following == player.PlayerID {
+			followers = append(followers, spectator)
+		}
+		spectator.RUnlock()
+	}
+	w.playersMutex.RUnlock()
+
+	for _, follower := range followers {
+		w.follow(follower, uuid.Nil)
+	}
+}
+
+func (w *World) isPlaying(playerID uuid.UUID) bool {
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+	_, playing := w.players[playerID]
+	return playing
+}
+
+// eatenSpectators counts the spectators that were eaten and can respawn,
+// leaving out the connections that only watch. The players lock must be
+// held.
+func (w *World) eatenSpectators() int {
+	eaten := 0
+	for _, spectator := range w.spectators {
+		if !spectator.watchOnly {
+			eaten++
+		}
+	}
+	return eaten
+}
@@ -1,25 +1,23 @@
 package galaxy
 
 import (
-	"log"
+	"log/slog"
+	"math"
 	"math/rand/v2"
 	"net/http"
-	"os"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"galaxy.io/server/config"
+	"galaxy.io/server/logging"
 	pb "galaxy.io/server/proto"
 	"github.com/google/uuid"
-)
-
-const (
-	WORLD_WIDTH  = 10_000
-	WORLD_HEIGHT = 10_000
+	"google.golang.org/protobuf/proto"
 )
 
 // PlayerID is a UUID v4 identifying a unique player.
-// This identifier will be shared with the database.
+// This identifier will be shared with the backend.
 type PlayerID uuid.UUID
 
 type Vector2D struct {
@@ -56,36 +54,24 @@ func VectorFromPacket(packet *pb.Vector2D) *Vector2D {
 	}
 }
 
-func randomPosition() *Vector2D {
+func randomPosition(rng *Random, world config.WorldConfig) *Vector2D {
 	return &Vector2D{
-		X: rand.Uint32N(WORLD_WIDTH),
-		Y: rand.Uint32N(WORLD_HEIGHT),
+		X: rng.Uint32N(world.Width),
+		Y: rng.Uint32N(world.Height),
 	}
 }
 
-func isPrivateServer() bool {
-	value, exists := os.LookupEnv("PRIVATE_SERVER")
-
-	if !exists {
-		log.Printf("Starting as a public server.")
-		return false // Assume false if the environment variable is not set
+// worldSeed returns the configured seed of the world, picking and logging
+// a fresh one when it is not set so the world can be replayed.
+func worldSeed(world config.WorldConfig) uint64 {
+	if world.Seed != 0 {
+		slog.Info("using configured world seed", "seed", world.Seed)
+		return world.Seed
 	}
 
-	// Convert the value to lowercase for case-insensitive comparison
-	lowerValue := strings.ToLower(value)
-
-	// Check for common true values
-	switch lowerValue {
-	case "true", "1", "yes":
-		log.Printf("Starting as a private server.")
-		return true
-	case "false", "0", "no":
-		log.Printf("Starting as a public server.")
-		return false
-	default:
-		log.Printf("We have no clue if you want a private server, going public.")
-		return false
-	}
+	seed := rand.Uint64()
+	slog.Info("using random world seed", "seed", seed)
+	return seed
 }
 
 // World holds all elements inside a current game, this includes players, bots and food.
@@ -97,30 +83,101 @@ type World struct {
 	foodMutex         sync.RWMutex
 	players           map[uuid.UUID]*Player
 	playersConnection map[uuid.UUID]*Player
+	// Eaten players still connected, by connection ID.
+	spectators        map[uuid.UUID]*Player
 	playersMutex      sync.RWMutex
 	connectionFactory ConnectionFactory
-	database          *Database
+	config            *config.Config
+	backend           *observedBackend
+	outbox            *Outbox
 	privateServer     bool
 	gameID            *uint32
 	savedPlayers      []PlayerData
+	banned            map[uuid.UUID]bool
+	muted             map[uuid.UUID]bool
+	// nil when the chat is disabled in this world
+	chat *Chat
+	// Last leader announced in the feed, only used by trackLeader.
+	lastLeader uuid.UUID
+	director   Director
+	// How long spectators see the world late, zero when they don't.
+	spectatorDelay time.Duration
+	clock          Clock
+	rng            *Random
+	recorder       *Recorder
+	startedAt      time.Time
+	// Set from a pause until the next private game starts.
+	paused       atomic.Bool
+	shuttingDown atomic.Bool
 }
 
-func NewWorld(factory ConnectionFactory) *World {
-	return &World{
+func NewWorld(cfg *config.Config, backend Backend, factory ConnectionFactory, clock Clock) *World {
+	rng := NewRandom(worldSeed(cfg.World))
+
+	if cfg.Server.Private {
+		slog.Info("starting as a private server")
+	} else {
+		slog.Info("starting as a public server")
+	}
+
+	w := &World{
 		players:           make(map[uuid.UUID]*Player),
 		playersConnection: make(map[uuid.UUID]*Player),
-		food:              createRandomFood(),
+		spectators:        make(map[uuid.UUID]*Player),
+		banned:            make(map[uuid.UUID]bool),
+		muted:             make(map[uuid.UUID]bool),
+		food:              createRandomFood(rng, cfg.World),
 		connectionFactory: factory,
-		database:          newDatabase(),
-		privateServer:     isPrivateServer(),
+		config:            cfg,
+		backend:           newObservedBackend(backend),
+		privateServer:     cfg.Server.Private,
+		clock:             clock,
+		rng:               rng,
+		startedAt:         clock.Now(),
+	}
+
+	if (cfg.Server.Private && cfg.Chat.Private) || (!cfg.Server.Private && cfg.Chat.Public) {
+		w.chat = NewChat(cfg.Chat)
 	}
+	if (cfg.Server.Private && cfg.Spectators.Private) || (!cfg.Server.Private && cfg.Spectators.Public) {
+		w.spectatorDelay = cfg.Spectators.Delay
+	}
+
+	outbox, err := NewOutbox(w.backend, cfg.Backend, clock)
+	if err != nil {
+		slog.Error("unable to open the outbox, keeping backend calls in memory", "dir", cfg.Backend.Outbox.Dir, "err", err)
+		memoryBackend := cfg.Backend
+		memoryBackend.Outbox.Dir = ""
+		outbox, _ = NewOutbox(w.backend, memoryBackend, clock)
+	}
+	w.outbox = outbox
+
+	w.registerMetrics()
+	go w.trackLeader()
+	go w.runLeaderboard()
+	go w.runDirector()
+
+	if cfg.Record.Dir != "" {
+		recorder, err := NewRecorder(RecorderConfig{
+			Dir:         cfg.Record.Dir,
+			RotateEvery: cfg.Record.RotateEvery,
+			MaxFileSize: cfg.Record.MaxFileSize,
+		}, w)
+		if err != nil {
+			slog.Error("unable to start match recorder, not recording", "err", err)
+		} else {
+			w.recorder = recorder
+		}
+	}
+
+	return w
 }
 
 func (w *World) checkForBots() {
 	for {
-		time.Sleep(10 * time.Second)
+		w.clock.Sleep(10 * time.Second)
 		w.playersMutex.RLock()
-		onlyBots := true
+		onlyBots := w.eatenSpectators() == 0
 		for _, player := range w.players {
 			if player.conn != nil {
 				onlyBots = false
@@ -133,17 +190,71 @@ func (w *World) checkForBots() {
 			return
 		}
 
-		if len(w.players) < 5 {
-			log.Printf("less than 5 players in game, creating bot")
-			bot := NewBot()
-			w.players[bot.player.PlayerID] = bot.player
-			w.broadcastNewPlayer(bot.player)
-			go bot.Start(w)
+		if len(w.players) < w.config.World.MinPlayers {
+			slog.Info("not enough players in game, creating bot", "players", len(w.players), "minPlayers", w.config.World.MinPlayers)
+			w.spawnBot()
+		}
+	}
+}
+
+// LEADER_TRACK_INTERVAL is how often the time spent first in the
+// leaderboard is accounted.
+const LEADER_TRACK_INTERVAL = time.Second
+
+// trackLeader adds the time spent first in the leaderboard to the stats of
+// the leader and announces every new one, until the world shuts down.
+func (w *World) trackLeader() {
+	for !w.shuttingDown.Load() {
+		w.clock.Sleep(LEADER_TRACK_INTERVAL)
+
+		leader := leaderOf(w.standings())
+		if leader == nil {
+			continue
+		}
+		leader.Stats.Lock()
+		leader.Stats.TimeAtTop += LEADER_TRACK_INTERVAL
+		leader.Stats.Unlock()
+		w.announceLeader(leader)
+	}
+}
+
+// connectedPlayer returns the player with the given ID, whether it is
+// playing or spectating.
+func (w *World) connectedPlayer(playerID uuid.UUID) (*Player, bool) {
+	if playerID == uuid.Nil {
+		return nil, false
+	}
+
+	w.playersMutex.RLock()
+	defer w.playersMutex.RUnlock()
+
+	if player, exists := w.players[playerID]; exists {
+		return player, true
+	}
+	for _, spectator := range w.spectators {
+		if spectator.PlayerID == playerID {
+			return spectator, true
 		}
 	}
+	return nil, false
+}
+
+func (w *World) spawnBot() *Bot {
+	bot := NewBot(w.config, w.clock, w.rng)
+	bot.player.UpdatePosition(w.spawnPosition(bot.player.Radius))
+	w.playersMutex.Lock()
+	w.players[bot.player.PlayerID] = bot.player
+	w.playersMutex.Unlock()
+	w.broadcastNewPlayer(bot.player)
+	go bot.Start(w)
+	return bot
 }
 
 func (w *World) sendEvent(player *Player, event *pb.Event) error {
+	if feed := player.feed.Load(); feed != nil {
+		feed.Push(event)
+		return nil
+	}
 	err := player.SendEvent(event)
 	return err
 	// if err != nil {
@@ -151,8 +262,13 @@ func (w *World) sendEvent(player *Player, event *pb.Event) error {
 }
 
 func (w *World) HandleNewConnection(writer http.ResponseWriter, r *http.Request) {
+	if w.shuttingDown.Load() {
+		http.Error(writer, "server shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
 	connectionID := uuid.New()
-	log.Printf("handling new connection, id = %v", connectionID)
+	slog.Info("handling new connection", "connectionID", connectionID, "remoteAddr", r.RemoteAddr)
 
 	operationHandler := func(operation *pb.Operation) {
 		w.handlePlayerOperation(connectionID, operation)
@@ -160,27 +276,42 @@ func (w *World) HandleNewConnection(writer http.ResponseWriter, r *http.Request)
 
 	conn, err := w.connectionFactory.NewConnection(writer, r, operationHandler)
 	if err != nil {
-		log.Printf("Error creating connection: %v", err)
+		slog.Error("error creating connection", "connectionID", connectionID, "err", err)
 		return
 	}
 
-	player := NewPlayer(connectionID, conn)
+	player := NewPlayer(connectionID, conn, w.rng, w.config.World)
+	player.RemoteAddr = r.RemoteAddr
+	player.ConnectedAt = w.clock.Now()
 	w.registerPlayer(player)
 }
 
 func (w *World) broadcastEvent(event *pb.Event) {
-	w.playersMutex.RLock()
-	defer w.playersMutex.RUnlock()
+	defer broadcastDuration.ObserveSince(time.Now())
+	w.recorder.RecordEvent(event)
 
+	// removing a player changes the maps, so it waits for the lock to be released
+	var failed []*Player
+	w.playersMutex.RLock()
 	for _, player := range w.players {
 		err := w.sendEvent(player, event)
 		if err != nil {
-			w.playersMutex.RUnlock()
-			log.Printf("deleting player %v becau", player.PlayerID.String())
-			w.removePlayer(player)
-			w.playersMutex.RLock()
+			w.playerLogger(player).Info("removing player after a failed broadcast", "err", err)
+			failed = append(failed, player)
 		}
 	}
+	for _, spectator := range w.spectators {
+		err := w.sendEvent(spectator, event)
+		if err != nil {
+			w.playerLogger(spectator).Info("removing spectator after a failed broadcast", "err", err)
+			failed = append(failed, spectator)
+		}
+	}
+	w.playersMutex.RUnlock()
+
+	for _, player := range failed {
+		w.removePlayer(player)
+	}
 }
 
 func (w *World) registerPlayer(player *Player) {
@@ -190,18 +321,81 @@ func (w *World) registerPlayer(player *Player) {
 }
 
 func (w *World) removePlayer(player *Player) {
-	log.Printf("removing player: %v", player.PlayerID.String())
+	w.playerLogger(player).Info("removing player")
 	w.playersMutex.Lock()
 
-	if _, exists := w.players[player.PlayerID]; !exists {
+	_, playing := w.players[player.PlayerID]
+	_, spectating := w.spectators[player.ConnectionID]
+	if !playing && !spectating {
 		w.playersMutex.Unlock()
 		return
 	}
 
 	delete(w.players, player.PlayerID)
+	delete(w.spectators, player.ConnectionID)
 	w.playersMutex.Unlock()
+	player.stopProtection()
+	w.undelay(player)
+	if w.chat != nil {
+		w.chat.forget(player.PlayerID)
+	}
+
+	if playing {
+		w.broadcastDestroyPlayer(player)
+		w.unfollow(player)
+		w.clock.Sleep(200 * time.Millisecond)
+	}
+	player.Disconnect()
+	if !player.IsBot() && !player.watchOnly {
+		player.Stats.Lock()
+		player.Stats.TimeEnd = w.clock.Now()
+		player.Stats.endLife(player.Stats.TimeEnd)
+		player.Stats.Unlock()
+		w.postAchievements(player)
+	}
 
-	// broadcast player left event
+	w.playersMutex.RLock()
+	empty := len(w.players) == 0 && w.eatenSpectators() == 0
+	w.playersMutex.RUnlock()
+
+	// spectators watching a private game don't take part in it, and may
+	// have come before it started
+	if w.privateServer && empty && !player.watchOnly && w.gameID != nil {
+		slog.Info("restarting private server as no players are online", "gameID", *w.gameID)
+		w.gameID = nil
+		w.recorder.Rotate()
+	}
+}
+
+// killPlayer takes an eaten player out of the world but keeps its
+// connection open, so it can spectate until it respawns or leaves. Bots
+// have nothing to come back to and are removed instead.
+func (w *World) killPlayer(player *Player) {
+	if player.IsBot() {
+		w.removePlayer(player)
+		return
+	}
+
+	w.playerLogger(player).Info("player died, spectating")
+	w.playersMutex.Lock()
+	if _, exists := w.players[player.PlayerID]; !exists {
+		w.playersMutex.Unlock()
+		return
+	}
+	delete(w.players, player.PlayerID)
+	w.spectators[player.ConnectionID] = player
+	w.playersMutex.Unlock()
+
+	w.broadcastDestroyPlayer(player)
+	w.unfollow(player)
+	w.delaySpectator(player)
+
+	player.Stats.Lock()
+	player.Stats.endLife(w.clock.Now())
+	player.Stats.Unlock()
+}
+
+func (w *World) broadcastDestroyPlayer(player *Player) {
 	event := &pb.Event{
 		EventType: pb.EventType_EvDestroyPlayer.Enum(),
 		EventData: &pb.Event_DestroyPlayerEvent{
@@ -212,15 +406,6 @@ func (w *World) removePlayer(player *Player) {
 	}
 
 	w.broadcastEvent(event)
-	time.Sleep(200 * time.Millisecond)
-	player.Disconnect()
-	player.Stats.TimeEnd = time.Now()
-	w.database.PostAchievements(player)
-
-	if w.privateServer && len(w.players) == 0 {
-		log.Printf("restarting private server as no players are online")
-		w.gameID = nil
-	}
 }
 
 func (w *World) broadcastNewPlayer(player *Player) {
@@ -228,19 +413,20 @@ func (w *World) broadcastNewPlayer(player *Player) {
 		EventType: pb.EventType_EvNewPlayer.Enum(),
 		EventData: &pb.Event_NewPlayerEvent{
 			NewPlayerEvent: &pb.NewPlayerEvent{
-				PlayerID: player.PlayerID[:],
-				Position: player.Position.toPacket(),
-				Radius:   &player.Radius,
-				Color:    &player.Color,
-				Skin:     player.Skin,
-				Username: &player.Username,
+				PlayerID:  player.PlayerID[:],
+				Position:  player.Position.toPacket(),
+				Radius:    &player.Radius,
+				Color:     &player.Color,
+				Skin:      player.Skin,
+				Username:  &player.Username,
+				Protected: proto.Bool(player.IsProtected()),
 			},
 		},
 	}
 
 	w.broadcastEvent(event)
 	w.playersMutex.Lock()
-	time.Sleep(100 * time.Millisecond)
+	w.clock.Sleep(100 * time.Millisecond)
 	w.playersMutex.Unlock()
 }
 
@@ -249,20 +435,21 @@ func (w *World) sendJoin(player *Player) {
 		EventType: pb.EventType_EvJoin.Enum(),
 		EventData: &pb.Event_JoinEvent{
 			JoinEvent: &pb.JoinEvent{
-				PlayerID: player.PlayerID[:],
-				Position: player.Position.toPacket(),
-				Radius:   &player.Radius,
-				Color:    &player.Color,
-				Skin:     player.Skin,
+				PlayerID:  player.PlayerID[:],
+				Position:  player.Position.toPacket(),
+				Radius:    &player.Radius,
+				Color:     &player.Color,
+				Skin:      player.Skin,
+				Protected: proto.Bool(player.IsProtected()),
 			},
 		},
 	}
 
-	log.Printf("sending join")
+	w.playerLogger(player).Debug("sending join")
 
 	err := w.sendEvent(player, event)
 	if err != nil {
-		log.Printf("deleting player %v becau", player.PlayerID.String())
+		w.playerLogger(player).Info("removing player after failing to send join", "err", err)
 		w.removePlayer(player)
 	}
 }
@@ -279,29 +466,29 @@ func (w *World) sendState(receiver *Player) {
 			EventType: pb.EventType_EvNewPlayer.Enum(),
 			EventData: &pb.Event_NewPlayerEvent{
 				NewPlayerEvent: &pb.NewPlayerEvent{
-					PlayerID: player.PlayerID[:],
-					Position: player.Position.toPacket(),
-					Radius:   &player.Radius,
-					Color:    &player.Color,
-					Skin:     player.Skin,
-					Username: &player.Username,
+					PlayerID:  player.PlayerID[:],
+					Position:  player.Position.toPacket(),
+					Radius:    &player.Radius,
+					Color:     &player.Color,
+					Skin:      player.Skin,
+					Username:  &player.Username,
+					Protected: proto.Bool(player.IsProtected()),
 				},
 			},
 		}
 
-		log.Printf("sending state %v to player %v", player.ConnectionID, receiver.ConnectionID)
+		w.playerLogger(receiver).Debug("sending state", "of", player.PlayerID)
 
 		err := w.sendEvent(receiver, event)
 		if err != nil {
-			log.Printf("deleting player %v becau", player.PlayerID.String())
+			w.playerLogger(receiver).Info("removing player after failing to send state", "err", err)
 			w.playersMutex.RUnlock()
 			w.removePlayer(receiver)
-			w.playersMutex.RLock()
 			return
 		}
-		time.Sleep(100 * time.Millisecond)
+		w.clock.Sleep(100 * time.Millisecond)
 	}
-	time.Sleep(200 * time.Millisecond)
+	w.clock.Sleep(200 * time.Millisecond)
 
 	var pbFoods []*pb.Food
 
@@ -321,24 +508,123 @@ func (w *World) sendState(receiver *Player) {
 	}
 
 	w.sendEvent(receiver, event)
-	time.Sleep(200 * time.Millisecond)
+	w.clock.Sleep(200 * time.Millisecond)
 	w.playersMutex.RUnlock()
 }
 
+// snapshotEvents returns the events needed to rebuild the current state of
+// the world from scratch: every player followed by all the food.
+func (w *World) snapshotEvents() []*pb.Event {
+	var events []*pb.Event
+
+	w.playersMutex.RLock()
+	for _, player := range w.players {
+		events = append(events, &pb.Event{
+			EventType: pb.EventType_EvNewPlayer.Enum(),
+			EventData: &pb.Event_NewPlayerEvent{
+				NewPlayerEvent: &pb.NewPlayerEvent{
+					PlayerID:  player.PlayerID[:],
+					Position:  player.GetPosition().toPacket(),
+					Radius:    &player.Radius,
+					Color:     &player.Color,
+					Skin:      player.Skin,
+					Username:  &player.Username,
+					Protected: proto.Bool(player.IsProtected()),
+				},
+			},
+		})
+	}
+	w.playersMutex.RUnlock()
+
+	var pbFoods []*pb.Food
+	w.foodMutex.RLock()
+	for _, food := range w.food {
+		pbFoods = append(pbFoods, &pb.Food{
+			Position: food.position.toPacket(),
+			Color:    &food.color,
+		})
+	}
+	w.foodMutex.RUnlock()
+
+	events = append(events, &pb.Event{
+		EventType: pb.EventType_EvNewFood.Enum(),
+		EventData: &pb.Event_NewFoodEvent{
+			NewFoodEvent: &pb.NewFoodEvent{
+				Food: pbFoods,
+			},
+		},
+	})
+
+	return events
+}
+
+func (w *World) replayHeader(now time.Time) *pb.ReplayHeader {
+	header := &pb.ReplayHeader{
+		Seed:          proto.Uint64(w.rng.Seed()),
+		WorldWidth:    proto.Uint32(w.config.World.Width),
+		WorldHeight:   proto.Uint32(w.config.World.Height),
+		PrivateServer: proto.Bool(w.privateServer),
+		StartTime:     proto.Int64(now.UnixNano()),
+	}
+	if gameID := w.gameID; gameID != nil {
+		header.GameID = proto.Uint32(*gameID)
+	}
+	return header
+}
+
+// playerLogger returns a logger carrying the identifiers of the player and
+// of the running private game.
+func (w *World) playerLogger(player *Player) *slog.Logger {
+	logger := player.Logger()
+	if gameID := w.gameID; gameID != nil {
+		logger = logger.With("gameID", *gameID)
+	}
+	return logger
+}
+
+func (w *World) operationLogger(player *Player, operationType pb.OperationType) *slog.Logger {
+	return w.playerLogger(player).With("operation", operationType)
+}
+
 /// OPERATIONS
 
+// moveLogSampler limits the logs of move operations, which arrive several
+// times a second from every player.
+var moveLogSampler = logging.NewSampler(1000)
+
 func (w *World) handlePlayerOperation(connectionID uuid.UUID, operation *pb.Operation) {
-	if (*operation.OperationType != pb.OperationType_OpMove) {
-		log.Printf("handling new operation, player = %v, op = %v", connectionID, operation)
+	if operation.GetOperationType() != pb.OperationType_OpMove || moveLogSampler.Allow() {
+		slog.Debug("handling new operation", "connectionID", connectionID, "operation", operation.GetOperationType(), "data", operation)
 	}
+	if w.shuttingDown.Load() {
+		return
+	}
+	w.recorder.RecordOperation(connectionID, operation)
+	operationsTotal.With(operation.GetOperationType().String()).Inc()
+
 	w.playersMutex.RLock()
 	player, exists := w.playersConnection[connectionID]
+	_, spectating := w.spectators[connectionID]
+	watchOnly := spectating && player.watchOnly
 	w.playersMutex.RUnlock()
 
 	if !exists {
 		return
 	}
 
+	if spectating {
+		switch operation.GetOperationType() {
+		case pb.OperationType_OpJoin:
+			if !watchOnly {
+				w.operationLogger(player, operation.GetOperationType()).Debug("ignoring operation from a dead player")
+				return
+			}
+		case pb.OperationType_OpMove, pb.OperationType_OpEatFood, pb.OperationType_OpEatPlayer:
+			w.operationLogger(player, operation.GetOperationType()).Debug("ignoring operation from a dead player")
+			return
+		}
+	}
+
 	switch *operation.OperationType {
 	case pb.OperationType_OpJoin:
 		w.operationJoin(player, operation.GetJoinOperation())
@@ -352,8 +638,14 @@ func (w *World) handlePlayerOperation(connectionID uuid.UUID, operation *pb.Oper
 		w.removePlayer(player)
 	case pb.OperationType_OpPause:
 		w.pauseServer()
+	case pb.OperationType_OpRespawn:
+		w.operationRespawn(player)
+	case pb.OperationType_OpChat:
+		w.operationChat(player, operation.GetChatOperation())
+	case pb.OperationType_OpSpectate:
+		w.operationSpectate(player, operation.GetSpectateOperation())
 	default:
-		log.Printf("unimplemented event: %v", operation.OperationType.Enum().String())
+		w.operationLogger(player, operation.GetOperationType()).Warn("unimplemented operation")
 		return
 	}
 }
@@ -361,7 +653,7 @@ func (w *World) handlePlayerOperation(connectionID uuid.UUID, operation *pb.Oper
 func (w *World) pauseServer() {
 	if w.gameID == nil {
 		// pause is not implemented in public matches
-		log.Printf("pausing in a public server")
+		slog.Warn("pausing in a public server")
 		return
 	}
 
@@ -370,27 +662,47 @@ func (w *World) pauseServer() {
 		EventData: &pb.Event_PauseEvent{},
 	}
 
-	log.Printf("broadcasting pause")
+	// paused until the next private game starts
+	w.paused.Store(true)
+
+	slog.Info("broadcasting pause", "gameID", *w.gameID)
 	w.broadcastEvent(pauseEvent)
 	w.playersMutex.Lock()
-	w.database.PausePrivateGame(*w.gameID)
-	w.database.UpdateValues(w)
+	w.savePrivateGame(*w.gameID)
 
 	for id, player := range w.players {
 		player.Disconnect()
 		delete(w.players, id)
 	}
+	for id, spectator := range w.spectators {
+		spectator.Disconnect()
+		delete(w.spectators, id)
+		w.undelay(spectator)
+	}
 	w.playersMutex.Unlock()
 
-	log.Printf("restarting private server")
+	slog.Info("restarting private server", "gameID", *w.gameID)
 	w.gameID = nil
+	w.recorder.Rotate()
 }
 
 func (w *World) operationJoin(player *Player, joinOperation *pb.JoinOperation) {
-	log.Printf("player joined %v, data=%v", player, joinOperation)
+	logger := w.operationLogger(player, pb.OperationType_OpJoin)
+	logger.Info("player joined", "data", joinOperation)
 	playerID, err := uuid.FromBytes(joinOperation.PlayerID)
 	if err != nil {
-		log.Printf("warn: unable to parse playerID: %v", err)
+		logger.Warn("unable to parse playerID", "err", err)
+		return
+	}
+	logger = logger.With("playerID", playerID)
+	if w.isBanned(playerID) {
+		logger.Warn("banned player tried joining, kicking him")
+		player.Disconnect()
+		return
+	}
+	if w.isFull() {
+		logger.Warn("player tried joining a full server, kicking him")
+		player.Disconnect()
 		return
 	}
 	player.UpdatePlayerID(playerID)
@@ -400,20 +712,22 @@ func (w *World) operationJoin(player *Player, joinOperation *pb.JoinOperation) {
 		player.UpdateSkin(*joinOperation.Skin)
 	}
 
+	restored := false
 	if w.privateServer {
 		if joinOperation.GameID == nil {
-			log.Printf("ERROR: a player tried joining a private server without gameID, kicking him.")
+			logger.Error("a player tried joining a private server without gameID, kicking him")
 			return
 		}
 
 		if w.gameID == nil {
 			w.gameID = joinOperation.GameID
-			log.Printf("set up gameID: %v", *w.gameID)
-			w.database.StartPrivateGame(*w.gameID)
-			w.savedPlayers = w.database.GetValues(*w.gameID)
+			w.paused.Store(false)
+			logger.Info("set up gameID", "gameID", *w.gameID)
+			w.recorder.Rotate()
+			w.startPrivateGame(*w.gameID)
 		} else {
 			if *w.gameID != *joinOperation.GameID {
-				log.Printf("ERROR: a player tried joining a private server with the wrong gameID, kicking him. gameID = %v", *joinOperation.GameID)
+				logger.Error("a player tried joining a private server with the wrong gameID, kicking him", "gameID", *w.gameID, "wrongGameID", *joinOperation.GameID)
 				return
 			}
 		}
@@ -428,17 +742,27 @@ func (w *World) operationJoin(player *Player, joinOperation *pb.JoinOperation) {
 					Y: savedPlayer.Y,
 				})
 				player.UpdateRadius(savedPlayer.Score * 10)
+				restored = true
 				break
 			}
 		}
 	}
 
+	if !restored {
+		player.UpdatePosition(w.spawnPosition(player.Radius))
+	} else if !w.isSafeSpawn(player.GetPosition(), player.Radius) {
+		logger.Info("saved position is next to a larger player, moving it")
+		player.UpdatePosition(w.spawnPosition(player.Radius))
+	}
+	w.protect(player)
+	w.undelay(player)
+
 	w.sendJoin(player)
-	time.Sleep(200*time.Millisecond)
+	w.clock.Sleep(200 * time.Millisecond)
 	w.sendState(player)
 
 	w.playersMutex.Lock()
-	if len(w.players) == 0 {
+	if len(w.players) == 0 && w.eatenSpectators() == 0 {
 		// first player
 		if !w.privateServer {
 			// only in public matches
@@ -446,23 +770,62 @@ func (w *World) operationJoin(player *Player, joinOperation *pb.JoinOperation) {
 		}
 	}
 	w.players[player.PlayerID] = player
+	delete(w.spectators, player.ConnectionID)
+	player.watchOnly = false
 	w.playersMutex.Unlock()
 
 	w.broadcastNewPlayer(player)
 
 	player.Stats.Lock()
-	player.Stats.TimeStart = time.Now()
+	player.Stats.TimeStart = w.clock.Now()
+	player.Stats.startLife(player.Stats.TimeStart, player.Radius)
+	player.Stats.Unlock()
+}
+
+// operationRespawn puts an eaten player back in the world with a new
+// life, keeping its connection and the stats of the session.
+func (w *World) operationRespawn(player *Player) {
+	logger := w.operationLogger(player, pb.OperationType_OpRespawn)
+	position := w.spawnPosition(w.config.World.StartingRadius)
+
+	w.playersMutex.Lock()
+	if _, spectating := w.spectators[player.ConnectionID]; !spectating || player.watchOnly {
+		w.playersMutex.Unlock()
+		logger.Warn("tried respawning while not dead")
+		return
+	}
+	delete(w.spectators, player.ConnectionID)
+	w.undelay(player)
+	player.UpdatePosition(position)
+	player.UpdateRadius(w.config.World.StartingRadius)
+	w.players[player.PlayerID] = player
+	w.playersMutex.Unlock()
+
+	logger.Info("player respawned")
+	w.protect(player)
+	w.sendJoin(player)
+	w.broadcastNewPlayer(player)
+
+	player.Stats.Lock()
+	player.Stats.startLife(w.clock.Now(), player.Radius)
 	player.Stats.Unlock()
 }
 
 func (w *World) operationPlayerMove(player *Player, moveOperation *pb.MoveOperation) {
 	if moveOperation == nil {
-		log.Printf("nil operation in playerMove, player = %v", player.PlayerID.String())
+		w.operationLogger(player, pb.OperationType_OpMove).Warn("nil operation in playerMove")
 		return
 	}
 	// TODO: check for cheaters
+	previous := player.GetPosition()
 	player.UpdatePosition(VectorFromPacket(moveOperation.Position))
 
+	player.Stats.Lock()
+	player.Stats.Life.DistanceTravelled += math.Hypot(float64(player.Position.X)-float64(previous.X), float64(player.Position.Y)-float64(previous.Y))
+	player.Stats.Unlock()
+
+	w.checkProtectionDistance(player)
+
 	// broadcast the movement to all the players
 	playerIDBytes, _ := player.PlayerID.MarshalBinary()
 	moveEvent := &pb.Event{
@@ -479,8 +842,15 @@ func (w *World) operationPlayerMove(player *Player, moveOperation *pb.MoveOperat
 }
 
 func (w *World) operationPlayerEatFood(player *Player, operation *pb.EatFoodOperation) {
+	previousRadius := player.Radius
 	player.UpdateRadius(*operation.NewRadius)
 
+	player.Stats.Lock()
+	player.Stats.Life.FoodEaten++
+	player.Stats.Unlock()
+
+	w.endProtection(player)
+
 	foodPos := VectorFromPacket(operation.FoodPosition)
 	w.foodMutex.Lock()
 	for i, f := range w.food {
@@ -488,8 +858,8 @@ func (w *World) operationPlayerEatFood(player *Player, operation *pb.EatFoodOper
 			w.food = append(w.food[:i], w.food[i+1:]...)
 			// add new food
 			newFood := Food{
-				position: *randomPosition(),
-				color:    randomColor(),
+				position: *randomPosition(w.rng, w.config.World),
+				color:    randomColor(w.rng),
 			}
 			w.food = append(w.food, newFood)
 
@@ -529,27 +899,68 @@ func (w *World) operationPlayerEatFood(player *Player, operation *pb.EatFoodOper
 	}
 
 	w.broadcastEvent(eventGrow)
-	time.Sleep(15*time.Millisecond)
+	w.clock.Sleep(15*time.Millisecond)
 	w.broadcastEvent(eventFoodDestroy)
+
+	w.announceMilestones(player, previousRadius, player.Radius)
+}
+
+// sendGameOver tells an eaten player who ate them and how their life went.
+func (w *World) sendGameOver(player *Player, killer *Player) {
+	rank := w.rank(player)
+
+	player.Stats.Lock()
+	gameOver := &pb.GameOverEvent{
+		KillerID:       killer.PlayerID[:],
+		KillerUsername: proto.String(killer.Username),
+		FinalRadius:    proto.Uint32(player.Radius),
+		PeakRadius:     proto.Uint32(player.Stats.Life.PeakRadius),
+		Kills:          proto.Uint32(player.Stats.Life.Kills),
+		FoodEaten:      proto.Uint32(player.Stats.Life.FoodEaten),
+		TimeAlive:      proto.Int64(w.clock.Now().Sub(player.Stats.Life.Start).Milliseconds()),
+		Rank:           &rank,
+	}
+	player.Stats.Unlock()
+
+	event := &pb.Event{
+		EventType: pb.EventType_EvGameOver.Enum(),
+		EventData: &pb.Event_GameOverEvent{
+			GameOverEvent: gameOver,
+		},
+	}
+	if err := w.sendEvent(player, event); err != nil {
+		w.playerLogger(player).Debug("unable to send game over", "err", err)
+	}
 }
 
 func (w *World) operationEatPlayer(player *Player, operation *pb.EatPlayerOperation) {
-	log.Printf("operationEatPlayer, player = %v, operation = %v", player.PlayerID.String(), operation.String())
+	logger := w.operationLogger(player, pb.OperationType_OpEatPlayer)
+	logger.Debug("eating player", "data", operation)
 
 	playerToEatID, _ := uuid.FromBytes(operation.PlayerEaten)
 	w.playersMutex.RLock()
 	playerToEat, exists := w.players[playerToEatID]
 	w.playersMutex.RUnlock()
 	if !exists {
-		log.Printf("...trying to eat a dead player")
+		logger.Debug("trying to eat a dead player")
+		return
+	}
+
+	if player.IsProtected() {
+		logger.Debug("tried to eat a player while protected", "eatenPlayerID", playerToEat.PlayerID)
+		return
+	}
+	if playerToEat.IsProtected() {
+		logger.Debug("tried to eat a protected player", "eatenPlayerID", playerToEat.PlayerID)
 		return
 	}
 
 	if player.Radius <= playerToEat.Radius {
-		log.Printf("player %v tried to eat player %v while being equal or smaller size", player.ConnectionID, playerToEat.ConnectionID)
+		logger.Warn("tried to eat a player while being equal or smaller size", "eatenPlayerID", playerToEat.PlayerID)
 		return
 	}
 
+	previousRadius := player.Radius
 	player.UpdateRadius(*operation.NewRadius)
 
 	playerIDBytes, _ := player.PlayerID.MarshalBinary()
@@ -583,11 +994,19 @@ func (w *World) operationEatPlayer(player *Player, operation *pb.EatPlayerOperat
 		return
 	}
 
-	w.removePlayer(playerEaten)
+	playerEaten.Stats.Lock()
+	playerEaten.Stats.KilledBy = &player.PlayerID
+	playerEaten.Stats.Unlock()
+
+	w.sendGameOver(playerEaten, player)
+	w.killPlayer(playerEaten)
 	w.broadcastEvent(eventDestroyPlayer)
 	w.broadcastEvent(eventGrow)
 
 	player.Stats.Lock()
-	player.Stats.KilledPlayers++
+	player.Stats.recordKill()
 	player.Stats.Unlock()
+
+	w.announceKill(player, playerEaten)
+	w.announceMilestones(player, previousRadius, player.Radius)
 }
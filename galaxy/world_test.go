@@ -0,0 +1,418 @@
+package galaxy
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"galaxy.io/server/config"
+	pb "galaxy.io/server/proto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// testConnection records the events sent to a player.
+type testConnection struct {
+	mutex  sync.Mutex
+	events []*pb.Event
+	closed bool
+	done   chan struct{}
+}
+
+func newTestConnection() *testConnection {
+	return &testConnection{done: make(chan struct{})}
+}
+
+func (c *testConnection) SendEvent(event *pb.Event) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return errors.New("connection closed")
+	}
+	c.events = append(c.events, proto.Clone(event).(*pb.Event))
+	return nil
+}
+
+func (c *testConnection) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+}
+
+func (c *testConnection) Done() <-chan struct{} {
+	return c.done
+}
+
+// sent returns every event sent to the player.
+func (c *testConnection) sent() []*pb.Event {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return slices.Clone(c.events)
+}
+
+// isClosed reports whether the server closed the connection.
+func (c *testConnection) isClosed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.closed
+}
+
+// received returns the events of a type sent to the player.
+func (c *testConnection) received(eventType pb.EventType) []*pb.Event {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var events []*pb.Event
+	for _, event := range c.events {
+		if event.GetEventType() == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// worldSleepers is how many goroutines of a new world sleep on its clock:
+// trackLeader, runLeaderboard and runDirector.
+const worldSleepers = 3
+
+// newTestWorld returns a public world driven by a manual clock, with its
+// backend kept in memory. change tweaks the configuration first.
+func newTestWorld(t *testing.T, change func(cfg *config.Config)) (*World, *ManualClock, *MemoryBackend) {
+	t.Helper()
+
+	cfg := config.Default()
+	cfg.World.Seed = 1
+	cfg.World.SpawnProtection = 0
+	cfg.Backend.Outbox.Dir = ""
+	cfg.Record.Dir = ""
+	if change != nil {
+		change(cfg)
+	}
+
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	backend := NewMemoryBackend()
+	w := NewWorld(cfg, backend, nil, clock)
+	t.Cleanup(func() {
+		w.shuttingDown.Store(true)
+	})
+	clock.BlockUntil(worldSleepers)
+	return w, clock, backend
+}
+
+// addTestPlayer puts a human player in the world right away, without the
+// waits of a join.
+func addTestPlayer(w *World, position Vector2D, radius uint32) (*Player, *testConnection) {
+	conn := newTestConnection()
+	player := NewPlayer(uuid.New(), conn, w.rng, w.config.World)
+	player.UpdatePlayerID(uuid.New())
+	player.UpdatePosition(&position)
+	player.UpdateRadius(radius)
+
+	w.playersMutex.Lock()
+	w.playersConnection[player.ConnectionID] = player
+	w.players[player.PlayerID] = player
+	w.playersMutex.Unlock()
+	return player, conn
+}
+
+// advanceUntil moves the clock forward a step at a time until done reports
+// true, failing the test once limit has passed. Goroutines that don't sleep
+// on the clock get a moment to run between steps.
+func advanceUntil(t *testing.T, clock *ManualClock, step time.Duration, limit time.Duration, done func() bool) {
+	t.Helper()
+	for advanced := time.Duration(0); !done(); advanced += step {
+		if advanced > limit {
+			t.Fatalf("still waiting after %v", limit)
+		}
+		time.Sleep(time.Millisecond)
+		clock.Advance(step)
+	}
+}
+
+// run calls f in its own goroutine and returns whether it finished, for
+// code that sleeps on the manual clock.
+func run(f func()) func() bool {
+	var finished atomic.Bool
+	go func() {
+		f()
+		finished.Store(true)
+	}()
+	return finished.Load
+}
+
+func TestCheckForBots(t *testing.T) {
+	tests := []struct {
+		name       string
+		minPlayers int
+		advance    time.Duration
+		want       int
+	}{
+		{"none before the first check", 4, 9 * time.Second, 0},
+		{"one bot every check", 4, 25 * time.Second, 2},
+		{"stops at the minimum", 3, time.Minute, 2},
+		{"none when the world is full enough", 1, 30 * time.Second, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.MinPlayers = test.minPlayers
+				cfg.World.Food = 0
+			})
+			addTestPlayer(w, Vector2D{X: 100, Y: 100}, 50)
+
+			go w.checkForBots()
+			clock.BlockUntil(worldSleepers + 1)
+			clock.Advance(test.advance)
+
+			if bots := w.Stats().Bots; bots != test.want {
+				t.Errorf("got %d bots, want %d", bots, test.want)
+			}
+		})
+	}
+}
+
+func TestBotStart(t *testing.T) {
+	start := Vector2D{X: 1000, Y: 1000}
+	tests := []struct {
+		name       string
+		food       Vector2D
+		advance    time.Duration
+		wantX      uint32
+		wantGrowth bool
+	}{
+		{"heads for the food", Vector2D{X: 1200, Y: 1000}, 600 * time.Millisecond, 1100, false},
+		{"stays put without food in range", Vector2D{X: 3000, Y: 1000}, 600 * time.Millisecond, 1000, false},
+		{"eats food in reach", Vector2D{X: 1003, Y: 1000}, 0, 1000, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.Food = 0
+			})
+			w.food = []Food{{position: test.food}}
+
+			bot := NewBot(w.config, clock, w.rng)
+			bot.player.UpdatePosition(&start)
+			done := run(func() { bot.Start(w) })
+			clock.BlockUntil(worldSleepers + 1)
+			clock.Advance(test.advance)
+
+			position := bot.player.GetPosition()
+			if position.X != test.wantX || position.Y != start.Y {
+				t.Errorf("got position %+v, want {X:%d Y:%d}", *position, test.wantX, start.Y)
+			}
+			if grew := bot.player.Radius > w.config.World.StartingRadius; grew != test.wantGrowth {
+				t.Errorf("got growth %v, want %v", grew, test.wantGrowth)
+			}
+
+			bot.player.disconnect = true
+			advanceUntil(t, clock, 60*time.Millisecond, time.Second, done)
+		})
+	}
+}
+
+func TestTimePlayedAchievement(t *testing.T) {
+	tests := []struct {
+		name   string
+		played time.Duration
+		want   uint32
+	}{
+		{"left right away", 0, 0},
+		{"a minute and a half", 90 * time.Second, 90},
+		{"rounds down", 10*time.Second + 700*time.Millisecond, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, backend := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.MinPlayers = 0
+			})
+
+			conn := newTestConnection()
+			player := NewPlayer(uuid.New(), conn, w.rng, w.config.World)
+			w.registerPlayer(player)
+			playerID := uuid.New()
+			joined := run(func() {
+				w.operationJoin(player, &pb.JoinOperation{
+					PlayerID: playerID[:],
+					Username: proto.String("tester"),
+					Color:    proto.Uint32(Red),
+				})
+			})
+			advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, joined)
+
+			clock.Advance(test.played)
+			// removing a player waits for the destroy to go out
+			removed := run(func() { w.removePlayer(player) })
+			advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, removed)
+
+			var got []Achievement
+			advanceUntil(t, clock, time.Second, time.Minute, func() bool {
+				got = achievementsOf(backend, playerID.String(), ACHIEVEMENT_TIME_PLAYED)
+				return len(got) > 0
+			})
+			if len(got) != 1 || got[0].Quantity != test.want {
+				t.Errorf("got %+v, want a single %s of %d", got, ACHIEVEMENT_TIME_PLAYED, test.want)
+			}
+		})
+	}
+}
+
+// Only humans that played get achievements, bots have no user to post them
+// for.
+func TestRemovedPlayerAchievements(t *testing.T) {
+	tests := []struct {
+		name       string
+		bot        bool
+		wantPosted bool
+	}{
+		{"a human leaving", false, true},
+		{"a bot eaten", true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, backend := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.MinPlayers = 0
+			})
+			player, _ := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+			if test.bot {
+				player.conn = nil
+			}
+
+			// eaten bots are removed right away
+			removed := run(func() {
+				if test.bot {
+					w.killPlayer(player)
+				} else {
+					w.removePlayer(player)
+				}
+			})
+			advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, removed)
+			clock.Advance(time.Minute)
+			advanceUntil(t, clock, time.Second, time.Minute, func() bool {
+				return w.outbox.Pending() == 0
+			})
+
+			posted := len(achievementsOf(backend, player.PlayerID.String(), ACHIEVEMENT_TIME_PLAYED)) > 0
+			if posted != test.wantPosted {
+				t.Errorf("got achievements posted %v, want %v", posted, test.wantPosted)
+			}
+		})
+	}
+}
+
+// achievementsOf returns the achievements of a kind posted for a user.
+func achievementsOf(backend *MemoryBackend, userID string, kind string) []Achievement {
+	var found []Achievement
+	for _, achievement := range backend.Achievements() {
+		if achievement.UserID == userID && achievement.Kind == kind {
+			found = append(found, achievement)
+		}
+	}
+	return found
+}
+
+func TestBroadcastEvent(t *testing.T) {
+	tests := []struct {
+		name           string
+		playerFails    bool
+		spectatorFails bool
+		wantHumans     int
+		wantSpectators int
+	}{
+		{"everyone gets it", false, false, 2, 1},
+		{"a failed player is removed", true, false, 1, 1},
+		{"a failed spectator is removed", false, true, 2, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.World.MinPlayers = 0
+			})
+			_, playerConn := addTestPlayer(w, Vector2D{X: 100, Y: 100}, 50)
+			_, otherConn := addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+			spectatorConn := newTestConnection()
+			spectator := NewPlayer(uuid.New(), spectatorConn, w.rng, w.config.World)
+			spectator.watchOnly = true
+			w.playersMutex.Lock()
+			w.spectators[spectator.ConnectionID] = spectator
+			w.playersMutex.Unlock()
+			if test.playerFails {
+				playerConn.Close()
+			}
+			if test.spectatorFails {
+				spectatorConn.Close()
+			}
+
+			done := run(func() {
+				w.broadcastEvent(&pb.Event{EventType: pb.EventType_EvPause.Enum()})
+			})
+			advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, done)
+
+			stats := w.Stats()
+			if stats.Humans != test.wantHumans || stats.Spectators != test.wantSpectators {
+				t.Errorf("got %d humans and %d spectators, want %d and %d", stats.Humans, stats.Spectators, test.wantHumans, test.wantSpectators)
+			}
+			if got := len(otherConn.received(pb.EventType_EvPause)); got != 1 {
+				t.Errorf("other player got %d events, want 1", got)
+			}
+			if !test.playerFails && len(playerConn.received(pb.EventType_EvPause)) != 1 {
+				t.Errorf("player didn't get the event")
+			}
+		})
+	}
+}
+
+func TestRemovePlayerFromPrivateGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		running bool
+		// Whether a watch-only spectator leaves, instead of the last player.
+		spectator  bool
+		wantGameID bool
+	}{
+		{"spectator before a game started", false, true, false},
+		{"spectator of a running game", true, true, true},
+		{"last player of a running game", true, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, clock, _ := newTestWorld(t, func(cfg *config.Config) {
+				cfg.Server.Private = true
+				cfg.World.MinPlayers = 0
+			})
+			var spectate pb.SpectateOperation
+			if test.running {
+				w.gameID = proto.Uint32(7)
+				spectate.GameID = proto.Uint32(7)
+			}
+
+			var leaving *Player
+			if test.spectator {
+				leaving = NewPlayer(uuid.New(), newTestConnection(), w.rng, w.config.World)
+				w.registerPlayer(leaving)
+				spectating := run(func() { w.operationSpectate(leaving, &spectate) })
+				advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, spectating)
+			} else {
+				leaving, _ = addTestPlayer(w, Vector2D{X: 1000, Y: 1000}, 50)
+			}
+
+			removed := run(func() { w.removePlayer(leaving) })
+			advanceUntil(t, clock, 100*time.Millisecond, 10*time.Second, removed)
+
+			if (w.gameID != nil) != test.wantGameID {
+				t.Errorf("got game %v, want one %v", w.gameID, test.wantGameID)
+			}
+		})
+	}
+}
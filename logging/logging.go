@@ -0,0 +1,51 @@
+// Package logging configures the structured logger used by the server.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// Setup installs the default slog logger. Level is one of debug, info,
+// warn or error, and format is either text or json.
+func Setup(w io.Writer, level string, format string) error {
+	var logLevel slog.Level
+	if err := logLevel.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level %q", level)
+	}
+
+	options := &slog.HandlerOptions{Level: logLevel}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return fmt.Errorf("invalid log format %q", format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Sampler lets one of every n calls through, it keeps high volume paths
+// from flooding the logs.
+type Sampler struct {
+	every uint64
+	count atomic.Uint64
+}
+
+func NewSampler(every uint64) *Sampler {
+	return &Sampler{every: max(every, 1)}
+}
+
+// Allow reports whether this call should be logged, the first call always
+// is.
+func (s *Sampler) Allow() bool {
+	return (s.count.Add(1)-1)%s.every == 0
+}
@@ -1,30 +1,128 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
 	"os"
+	"os/signal"
 	"net/http"
+	"syscall"
 
+	"galaxy.io/server/config"
 	"galaxy.io/server/galaxy"
+	"galaxy.io/server/logging"
+	"galaxy.io/server/metrics"
 	"galaxy.io/server/websockets"
 )
 
 func main() {
-	wsFactory := &websockets.WebsocketFactory{}
+	cfg, args, err := config.Load(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("invalid configuration", "err", err)
+		os.Exit(2)
+	}
+
+	if err := logging.Setup(os.Stderr, cfg.Log.Level, cfg.Log.Format); err != nil {
+		slog.Error("unable to set up logging", "err", err)
+		os.Exit(2)
+	}
+
+	wsFactory := &websockets.WebsocketFactory{
+		MaxMessageSize: cfg.Websocket.MaxMessageSize,
+	}
+
+	if len(args) > 0 && args[0] == "replay" {
+		if len(args) != 2 {
+			slog.Error("usage: " + os.Args[0] + " [flags] replay <file>")
+			os.Exit(2)
+		}
+		serveReplay(cfg, wsFactory, args[1])
+		return
+	}
+
+	backend, err := galaxy.NewBackend(cfg.Backend)
+	if err != nil {
+		slog.Error("unable to set up the backend", "kind", cfg.Backend.Kind, "err", err)
+		os.Exit(1)
+	}
 
-	world := galaxy.NewWorld(wsFactory)
+	world := galaxy.NewWorld(cfg, backend, wsFactory, galaxy.NewRealClock())
 
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
 		world.HandleNewConnection(w, r)
 	})
 
-	ip := os.Getenv("GALAXY_SERVER_IP")
-	port := os.Getenv("GALAXY_SERVER_PORT")
+	http.Handle("/metrics", metrics.Handler())
+	http.HandleFunc("/healthz", world.HandleHealthz)
+	http.HandleFunc("/readyz", world.HandleReadyz)
+	http.HandleFunc("/status", world.HandleStatus)
+
+	if token := cfg.Server.AdminToken; token != "" {
+		http.Handle("/admin/", world.AdminHandler(token))
+	} else {
+		slog.Warn("no admin token configured, admin API disabled")
+	}
+
+	ip := cfg.Server.IP
+	port := cfg.Server.Port
+
+	server := &http.Server{Addr: ip + ":" + port}
 
-	log.Printf("server started in %v:%v", ip, port)
-	err := http.ListenAndServe(ip+":"+port, nil)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		slog.Info("server started", "ip", ip, "port", port)
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("ListenAndServe failed", "err", err)
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+
+	timeout := cfg.Server.ShutdownTimeout
+	slog.Info("shutting down", "timeout", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// stop accepting connections before closing the ones already open
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("error shutting down http server", "err", err)
+	}
+	if err := world.Shutdown(shutdownCtx, "server shutting down"); err != nil {
+		os.Exit(1)
+	}
+}
+
+// serveReplay serves a recorded match as a read only world.
+func serveReplay(cfg *config.Config, wsFactory *websockets.WebsocketFactory, path string) {
+	replay, err := galaxy.LoadReplay(path)
 	if err != nil {
-		log.Fatal("ListenAndServe: %v", err)
+		slog.Error("unable to load replay", "path", path, "err", err)
+		os.Exit(1)
 	}
 
+	server := galaxy.NewReplayServer(replay, wsFactory, galaxy.NewRealClock())
+
+	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		server.HandleNewConnection(w, r)
+	})
+
+	ip := cfg.Server.IP
+	port := cfg.Server.Port
+
+	slog.Info("replay server started", "ip", ip, "port", port, "path", path)
+	err = http.ListenAndServe(ip+":"+port, nil)
+	if err != nil {
+		slog.Error("ListenAndServe failed", "err", err)
+		os.Exit(1)
+	}
 }
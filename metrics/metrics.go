@@ -0,0 +1,388 @@
+// Package metrics implements the few metric types the server needs and
+// exposes them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBuckets are histogram buckets in seconds, suited for latencies
+// between a millisecond and a few seconds.
+var DefaultBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+type metric interface {
+	name() string
+	write(w io.Writer)
+}
+
+// Registry holds metrics by name, registering a name twice replaces the
+// previous metric.
+type Registry struct {
+	sync.Mutex
+	metrics map[string]metric
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string]metric),
+	}
+}
+
+// Default is the registry used by the New* functions and served by Handler.
+var Default = NewRegistry()
+
+func (r *Registry) register(m metric) {
+	r.Lock()
+	r.metrics[m.name()] = m
+	r.Unlock()
+}
+
+// Write writes every metric sorted by name.
+func (r *Registry) Write(w io.Writer) {
+	r.Lock()
+	var metrics []metric
+	for _, m := range r.metrics {
+		metrics = append(metrics, m)
+	}
+	r.Unlock()
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].name() < metrics[j].name()
+	})
+
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// Handler serves the default registry.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w := bufio.NewWriter(writer)
+		Default.Write(w)
+		w.Flush()
+	})
+}
+
+type desc struct {
+	metricName string
+	help       string
+	kind       string
+}
+
+func (d desc) name() string {
+	return d.metricName
+}
+
+func (d desc) writeHeader(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.metricName, strings.ReplaceAll(d.help, "\n", " "))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.metricName, d.kind)
+}
+
+// value is a float64 updated atomically.
+type value struct {
+	bits atomic.Uint64
+}
+
+func (v *value) add(delta float64) {
+	for {
+		old := v.bits.Load()
+		next := math.Float64bits(math.Float64frombits(old) + delta)
+		if v.bits.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
+
+func (v *value) set(f float64) {
+	v.bits.Store(math.Float64bits(f))
+}
+
+func (v *value) get() float64 {
+	return math.Float64frombits(v.bits.Load())
+}
+
+// Counter is a value that only goes up.
+type Counter struct {
+	value
+}
+
+func (c *Counter) Inc() {
+	c.add(1)
+}
+
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.add(delta)
+}
+
+type counter struct {
+	desc
+	Counter
+}
+
+func NewCounter(name string, help string) *Counter {
+	c := &counter{desc: desc{name, help, "counter"}}
+	Default.register(c)
+	return &c.Counter
+}
+
+func (c *counter) write(w io.Writer) {
+	c.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", c.metricName, formatFloat(c.get()))
+}
+
+// CounterVec is a set of counters partitioned by the value of a label.
+type CounterVec struct {
+	desc
+	label    string
+	mu       sync.RWMutex
+	counters map[string]*Counter
+}
+
+func NewCounterVec(name string, help string, label string) *CounterVec {
+	c := &CounterVec{
+		desc:     desc{name, help, "counter"},
+		label:    label,
+		counters: make(map[string]*Counter),
+	}
+	Default.register(c)
+	return c
+}
+
+// With returns the counter for the label value, creating it if needed.
+func (c *CounterVec) With(labelValue string) *Counter {
+	c.mu.RLock()
+	counter, exists := c.counters[labelValue]
+	c.mu.RUnlock()
+	if exists {
+		return counter
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if counter, exists = c.counters[labelValue]; !exists {
+		counter = &Counter{}
+		c.counters[labelValue] = counter
+	}
+	return counter
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.writeHeader(w)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, labelValue := range sortedKeys(c.counters) {
+		fmt.Fprintf(w, "%s{%s} %s\n", c.metricName, formatLabel(c.label, labelValue), formatFloat(c.counters[labelValue].get()))
+	}
+}
+
+// Gauge is a value that can go up and down.
+type Gauge struct {
+	value
+}
+
+func (g *Gauge) Set(f float64) {
+	g.set(f)
+}
+
+func (g *Gauge) Add(delta float64) {
+	g.add(delta)
+}
+
+func (g *Gauge) Inc() {
+	g.add(1)
+}
+
+func (g *Gauge) Dec() {
+	g.add(-1)
+}
+
+type gauge struct {
+	desc
+	Gauge
+}
+
+func NewGauge(name string, help string) *Gauge {
+	g := &gauge{desc: desc{name, help, "gauge"}}
+	Default.register(g)
+	return &g.Gauge
+}
+
+func (g *gauge) write(w io.Writer) {
+	g.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", g.metricName, formatFloat(g.get()))
+}
+
+type gaugeFunc struct {
+	desc
+	f func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is computed by f on every
+// scrape.
+func NewGaugeFunc(name string, help string, f func() float64) {
+	Default.register(&gaugeFunc{
+		desc: desc{name, help, "gauge"},
+		f:    f,
+	})
+}
+
+func (g *gaugeFunc) write(w io.Writer) {
+	g.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", g.metricName, formatFloat(g.f()))
+}
+
+// Histogram counts observations in cumulative buckets.
+type Histogram struct {
+	buckets []float64
+	counts  []atomic.Uint64
+	count   atomic.Uint64
+	sum     value
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{
+		buckets: buckets,
+		counts:  make([]atomic.Uint64, len(buckets)),
+	}
+}
+
+func (h *Histogram) Observe(f float64) {
+	for i, bound := range h.buckets {
+		if f <= bound {
+			h.counts[i].Add(1)
+		}
+	}
+	h.count.Add(1)
+	h.sum.add(f)
+}
+
+// ObserveSince observes the seconds elapsed since start.
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+func (h *Histogram) writeSeries(w io.Writer, name string, labels string) {
+	separator := ""
+	if labels != "" {
+		separator = ","
+	}
+	for i, bound := range h.buckets {
+		fmt.Fprintf(w, "%s_bucket{%s%sle=\"%s\"} %d\n", name, labels, separator, formatFloat(bound), h.counts[i].Load())
+	}
+	count := h.count.Load()
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, separator, count)
+
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, formatFloat(h.sum.get()))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, count)
+}
+
+type histogram struct {
+	desc
+	*Histogram
+}
+
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	h := &histogram{
+		desc:      desc{name, help, "histogram"},
+		Histogram: newHistogram(buckets),
+	}
+	Default.register(h)
+	return h.Histogram
+}
+
+func (h *histogram) write(w io.Writer) {
+	h.writeHeader(w)
+	h.writeSeries(w, h.metricName, "")
+}
+
+// HistogramVec is a set of histograms partitioned by the value of a label.
+type HistogramVec struct {
+	desc
+	label      string
+	buckets    []float64
+	mu         sync.RWMutex
+	histograms map[string]*Histogram
+}
+
+func NewHistogramVec(name string, help string, label string, buckets []float64) *HistogramVec {
+	h := &HistogramVec{
+		desc:       desc{name, help, "histogram"},
+		label:      label,
+		buckets:    buckets,
+		histograms: make(map[string]*Histogram),
+	}
+	Default.register(h)
+	return h
+}
+
+// With returns the histogram for the label value, creating it if needed.
+func (h *HistogramVec) With(labelValue string) *Histogram {
+	h.mu.RLock()
+	histogram, exists := h.histograms[labelValue]
+	h.mu.RUnlock()
+	if exists {
+		return histogram
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if histogram, exists = h.histograms[labelValue]; !exists {
+		histogram = newHistogram(h.buckets)
+		h.histograms[labelValue] = histogram
+	}
+	return histogram
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.writeHeader(w)
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, labelValue := range sortedKeys(h.histograms) {
+		h.histograms[labelValue].writeSeries(w, h.metricName, formatLabel(h.label, labelValue))
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabel(label string, value string) string {
+	return label + `="` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
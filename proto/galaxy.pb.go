@@ -33,20 +33,38 @@ const (
 	EventType_EvDestroyPlayer EventType = 6
 	EventType_EvJoin          EventType = 7
 	EventType_EvPause         EventType = 8
+	EventType_EvAnnouncement  EventType = 9
+	EventType_EvShutdown      EventType = 10
+	EventType_EvGameOver      EventType = 11
+	EventType_EvProtectionEnd EventType = 12
+	EventType_EvLeaderboard   EventType = 13
+	EventType_EvChat          EventType = 14
+	EventType_EvFeed          EventType = 15
+	EventType_EvSpectate      EventType = 16
+	EventType_EvCameraTarget  EventType = 17
 )
 
 // Enum value maps for EventType.
 var (
 	EventType_name = map[int32]string{
-		0: "EvUnused",
-		1: "EvNewFood",
-		2: "EvNewPlayer",
-		3: "EvPlayerMove",
-		4: "EvPlayerGrow",
-		5: "EvDestroyFood",
-		6: "EvDestroyPlayer",
-		7: "EvJoin",
-		8: "EvPause",
+		0:  "EvUnused",
+		1:  "EvNewFood",
+		2:  "EvNewPlayer",
+		3:  "EvPlayerMove",
+		4:  "EvPlayerGrow",
+		5:  "EvDestroyFood",
+		6:  "EvDestroyPlayer",
+		7:  "EvJoin",
+		8:  "EvPause",
+		9:  "EvAnnouncement",
+		10: "EvShutdown",
+		11: "EvGameOver",
+		12: "EvProtectionEnd",
+		13: "EvLeaderboard",
+		14: "EvChat",
+		15: "EvFeed",
+		16: "EvSpectate",
+		17: "EvCameraTarget",
 	}
 	EventType_value = map[string]int32{
 		"EvUnused":        0,
@@ -58,6 +76,15 @@ var (
 		"EvDestroyPlayer": 6,
 		"EvJoin":          7,
 		"EvPause":         8,
+		"EvAnnouncement":  9,
+		"EvShutdown":      10,
+		"EvGameOver":      11,
+		"EvProtectionEnd": 12,
+		"EvLeaderboard":   13,
+		"EvChat":          14,
+		"EvFeed":          15,
+		"EvSpectate":      16,
+		"EvCameraTarget":  17,
 	}
 )
 
@@ -88,37 +115,206 @@ func (EventType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_galaxy_proto_rawDescGZIP(), []int{0}
 }
 
+type ChatChannel int32
+
+const (
+	// Every player in the world, which is the game in private servers.
+	ChatChannel_ChatWorld ChatChannel = 0
+	// A single player.
+	ChatChannel_ChatDirect ChatChannel = 1
+)
+
+// Enum value maps for ChatChannel.
+var (
+	ChatChannel_name = map[int32]string{
+		0: "ChatWorld",
+		1: "ChatDirect",
+	}
+	ChatChannel_value = map[string]int32{
+		"ChatWorld":  0,
+		"ChatDirect": 1,
+	}
+)
+
+func (x ChatChannel) Enum() *ChatChannel {
+	p := new(ChatChannel)
+	*p = x
+	return p
+}
+
+func (x ChatChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_galaxy_proto_enumTypes[1].Descriptor()
+}
+
+func (ChatChannel) Type() protoreflect.EnumType {
+	return &file_proto_galaxy_proto_enumTypes[1]
+}
+
+func (x ChatChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatChannel.Descriptor instead.
+func (ChatChannel) EnumDescriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{1}
+}
+
+type FeedKind int32
+
+const (
+	FeedKind_FeedUnused    FeedKind = 0
+	FeedKind_FeedKill      FeedKind = 1
+	FeedKind_FeedBotEaten  FeedKind = 2
+	FeedKind_FeedNewLeader FeedKind = 3
+	FeedKind_FeedMilestone FeedKind = 4
+)
+
+// Enum value maps for FeedKind.
+var (
+	FeedKind_name = map[int32]string{
+		0: "FeedUnused",
+		1: "FeedKill",
+		2: "FeedBotEaten",
+		3: "FeedNewLeader",
+		4: "FeedMilestone",
+	}
+	FeedKind_value = map[string]int32{
+		"FeedUnused":    0,
+		"FeedKill":      1,
+		"FeedBotEaten":  2,
+		"FeedNewLeader": 3,
+		"FeedMilestone": 4,
+	}
+)
+
+func (x FeedKind) Enum() *FeedKind {
+	p := new(FeedKind)
+	*p = x
+	return p
+}
+
+func (x FeedKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeedKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_galaxy_proto_enumTypes[2].Descriptor()
+}
+
+func (FeedKind) Type() protoreflect.EnumType {
+	return &file_proto_galaxy_proto_enumTypes[2]
+}
+
+func (x FeedKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeedKind.Descriptor instead.
+func (FeedKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{2}
+}
+
+type CameraReason int32
+
+const (
+	CameraReason_CameraNone   CameraReason = 0
+	CameraReason_CameraLeader CameraReason = 1
+	// A player is about to eat another one.
+	CameraReason_CameraEat CameraReason = 2
+	// A player is going after a smaller one.
+	CameraReason_CameraChase CameraReason = 3
+)
+
+// Enum value maps for CameraReason.
+var (
+	CameraReason_name = map[int32]string{
+		0: "CameraNone",
+		1: "CameraLeader",
+		2: "CameraEat",
+		3: "CameraChase",
+	}
+	CameraReason_value = map[string]int32{
+		"CameraNone":   0,
+		"CameraLeader": 1,
+		"CameraEat":    2,
+		"CameraChase":  3,
+	}
+)
+
+func (x CameraReason) Enum() *CameraReason {
+	p := new(CameraReason)
+	*p = x
+	return p
+}
+
+func (x CameraReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CameraReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_galaxy_proto_enumTypes[3].Descriptor()
+}
+
+func (CameraReason) Type() protoreflect.EnumType {
+	return &file_proto_galaxy_proto_enumTypes[3]
+}
+
+func (x CameraReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CameraReason.Descriptor instead.
+func (CameraReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{3}
+}
+
 type OperationType int32
 
 const (
-	OperationType_OpUnused    OperationType = 0
-	OperationType_OpJoin      OperationType = 1
-	OperationType_OpLeave     OperationType = 2
-	OperationType_OpMove      OperationType = 3
-	OperationType_OpEatPlayer OperationType = 4
-	OperationType_OpEatFood   OperationType = 5
-	OperationType_OpPause     OperationType = 6
+	OperationType_OpUnused        OperationType = 0
+	OperationType_OpJoin          OperationType = 1
+	OperationType_OpLeave         OperationType = 2
+	OperationType_OpMove          OperationType = 3
+	OperationType_OpEatPlayer     OperationType = 4
+	OperationType_OpEatFood       OperationType = 5
+	OperationType_OpPause         OperationType = 6
+	OperationType_OpReplayControl OperationType = 7
+	OperationType_OpRespawn       OperationType = 8
+	OperationType_OpChat          OperationType = 9
+	OperationType_OpSpectate      OperationType = 10
 )
 
 // Enum value maps for OperationType.
 var (
 	OperationType_name = map[int32]string{
-		0: "OpUnused",
-		1: "OpJoin",
-		2: "OpLeave",
-		3: "OpMove",
-		4: "OpEatPlayer",
-		5: "OpEatFood",
-		6: "OpPause",
+		0:  "OpUnused",
+		1:  "OpJoin",
+		2:  "OpLeave",
+		3:  "OpMove",
+		4:  "OpEatPlayer",
+		5:  "OpEatFood",
+		6:  "OpPause",
+		7:  "OpReplayControl",
+		8:  "OpRespawn",
+		9:  "OpChat",
+		10: "OpSpectate",
 	}
 	OperationType_value = map[string]int32{
-		"OpUnused":    0,
-		"OpJoin":      1,
-		"OpLeave":     2,
-		"OpMove":      3,
-		"OpEatPlayer": 4,
-		"OpEatFood":   5,
-		"OpPause":     6,
+		"OpUnused":        0,
+		"OpJoin":          1,
+		"OpLeave":         2,
+		"OpMove":          3,
+		"OpEatPlayer":     4,
+		"OpEatFood":       5,
+		"OpPause":         6,
+		"OpReplayControl": 7,
+		"OpRespawn":       8,
+		"OpChat":          9,
+		"OpSpectate":      10,
 	}
 )
 
@@ -133,11 +329,11 @@ func (x OperationType) String() string {
 }
 
 func (OperationType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_galaxy_proto_enumTypes[1].Descriptor()
+	return file_proto_galaxy_proto_enumTypes[4].Descriptor()
 }
 
 func (OperationType) Type() protoreflect.EnumType {
-	return &file_proto_galaxy_proto_enumTypes[1]
+	return &file_proto_galaxy_proto_enumTypes[4]
 }
 
 func (x OperationType) Number() protoreflect.EnumNumber {
@@ -146,7 +342,7 @@ func (x OperationType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OperationType.Descriptor instead.
 func (OperationType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{1}
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{4}
 }
 
 type Vector2D struct {
@@ -214,6 +410,15 @@ type Event struct {
 	//	*Event_DestroyPlayerEvent
 	//	*Event_JoinEvent
 	//	*Event_PauseEvent
+	//	*Event_AnnouncementEvent
+	//	*Event_ShutdownEvent
+	//	*Event_GameOverEvent
+	//	*Event_ProtectionEndEvent
+	//	*Event_LeaderboardEvent
+	//	*Event_ChatEvent
+	//	*Event_FeedEvent
+	//	*Event_SpectateEvent
+	//	*Event_CameraTargetEvent
 	EventData     isEvent_EventData `protobuf_oneof:"eventData"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -335,6 +540,87 @@ func (x *Event) GetPauseEvent() *PauseEvent {
 	return nil
 }
 
+func (x *Event) GetAnnouncementEvent() *AnnouncementEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_AnnouncementEvent); ok {
+			return x.AnnouncementEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetShutdownEvent() *ShutdownEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_ShutdownEvent); ok {
+			return x.ShutdownEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetGameOverEvent() *GameOverEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_GameOverEvent); ok {
+			return x.GameOverEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetProtectionEndEvent() *ProtectionEndEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_ProtectionEndEvent); ok {
+			return x.ProtectionEndEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetLeaderboardEvent() *LeaderboardEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_LeaderboardEvent); ok {
+			return x.LeaderboardEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetChatEvent() *ChatEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_ChatEvent); ok {
+			return x.ChatEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetFeedEvent() *FeedEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_FeedEvent); ok {
+			return x.FeedEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSpectateEvent() *SpectateEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_SpectateEvent); ok {
+			return x.SpectateEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCameraTargetEvent() *CameraTargetEvent {
+	if x != nil {
+		if x, ok := x.EventData.(*Event_CameraTargetEvent); ok {
+			return x.CameraTargetEvent
+		}
+	}
+	return nil
+}
+
 type isEvent_EventData interface {
 	isEvent_EventData()
 }
@@ -371,6 +657,42 @@ type Event_PauseEvent struct {
 	PauseEvent *PauseEvent `protobuf:"bytes,9,opt,name=pauseEvent,oneof"`
 }
 
+type Event_AnnouncementEvent struct {
+	AnnouncementEvent *AnnouncementEvent `protobuf:"bytes,10,opt,name=announcementEvent,oneof"`
+}
+
+type Event_ShutdownEvent struct {
+	ShutdownEvent *ShutdownEvent `protobuf:"bytes,11,opt,name=shutdownEvent,oneof"`
+}
+
+type Event_GameOverEvent struct {
+	GameOverEvent *GameOverEvent `protobuf:"bytes,12,opt,name=gameOverEvent,oneof"`
+}
+
+type Event_ProtectionEndEvent struct {
+	ProtectionEndEvent *ProtectionEndEvent `protobuf:"bytes,13,opt,name=protectionEndEvent,oneof"`
+}
+
+type Event_LeaderboardEvent struct {
+	LeaderboardEvent *LeaderboardEvent `protobuf:"bytes,14,opt,name=leaderboardEvent,oneof"`
+}
+
+type Event_ChatEvent struct {
+	ChatEvent *ChatEvent `protobuf:"bytes,15,opt,name=chatEvent,oneof"`
+}
+
+type Event_FeedEvent struct {
+	FeedEvent *FeedEvent `protobuf:"bytes,16,opt,name=feedEvent,oneof"`
+}
+
+type Event_SpectateEvent struct {
+	SpectateEvent *SpectateEvent `protobuf:"bytes,17,opt,name=spectateEvent,oneof"`
+}
+
+type Event_CameraTargetEvent struct {
+	CameraTargetEvent *CameraTargetEvent `protobuf:"bytes,18,opt,name=cameraTargetEvent,oneof"`
+}
+
 func (*Event_NewPlayerEvent) isEvent_EventData() {}
 
 func (*Event_NewFoodEvent) isEvent_EventData() {}
@@ -387,14 +709,34 @@ func (*Event_JoinEvent) isEvent_EventData() {}
 
 func (*Event_PauseEvent) isEvent_EventData() {}
 
+func (*Event_AnnouncementEvent) isEvent_EventData() {}
+
+func (*Event_ShutdownEvent) isEvent_EventData() {}
+
+func (*Event_GameOverEvent) isEvent_EventData() {}
+
+func (*Event_ProtectionEndEvent) isEvent_EventData() {}
+
+func (*Event_LeaderboardEvent) isEvent_EventData() {}
+
+func (*Event_ChatEvent) isEvent_EventData() {}
+
+func (*Event_FeedEvent) isEvent_EventData() {}
+
+func (*Event_SpectateEvent) isEvent_EventData() {}
+
+func (*Event_CameraTargetEvent) isEvent_EventData() {}
+
 type NewPlayerEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerID      []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
-	Position      *Vector2D              `protobuf:"bytes,2,opt,name=position" json:"position,omitempty"`
-	Radius        *uint32                `protobuf:"varint,3,opt,name=radius" json:"radius,omitempty"`
-	Color         *uint32                `protobuf:"varint,4,opt,name=color" json:"color,omitempty"`
-	Skin          *string                `protobuf:"bytes,5,opt,name=skin" json:"skin,omitempty"`
-	Username      *string                `protobuf:"bytes,6,opt,name=username" json:"username,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	Position *Vector2D              `protobuf:"bytes,2,opt,name=position" json:"position,omitempty"`
+	Radius   *uint32                `protobuf:"varint,3,opt,name=radius" json:"radius,omitempty"`
+	Color    *uint32                `protobuf:"varint,4,opt,name=color" json:"color,omitempty"`
+	Skin     *string                `protobuf:"bytes,5,opt,name=skin" json:"skin,omitempty"`
+	Username *string                `protobuf:"bytes,6,opt,name=username" json:"username,omitempty"`
+	// The player has just spawned and can't eat or be eaten yet.
+	Protected     *bool `protobuf:"varint,7,opt,name=protected" json:"protected,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -471,13 +813,22 @@ func (x *NewPlayerEvent) GetUsername() string {
 	return ""
 }
 
+func (x *NewPlayerEvent) GetProtected() bool {
+	if x != nil && x.Protected != nil {
+		return *x.Protected
+	}
+	return false
+}
+
 type JoinEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerID      []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
-	Position      *Vector2D              `protobuf:"bytes,2,opt,name=position" json:"position,omitempty"`
-	Radius        *uint32                `protobuf:"varint,3,opt,name=radius" json:"radius,omitempty"`
-	Color         *uint32                `protobuf:"varint,4,opt,name=color" json:"color,omitempty"`
-	Skin          *string                `protobuf:"bytes,5,opt,name=skin" json:"skin,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	Position *Vector2D              `protobuf:"bytes,2,opt,name=position" json:"position,omitempty"`
+	Radius   *uint32                `protobuf:"varint,3,opt,name=radius" json:"radius,omitempty"`
+	Color    *uint32                `protobuf:"varint,4,opt,name=color" json:"color,omitempty"`
+	Skin     *string                `protobuf:"bytes,5,opt,name=skin" json:"skin,omitempty"`
+	// The player has just spawned and can't eat or be eaten yet.
+	Protected     *bool `protobuf:"varint,6,opt,name=protected" json:"protected,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -547,6 +898,13 @@ func (x *JoinEvent) GetSkin() string {
 	return ""
 }
 
+func (x *JoinEvent) GetProtected() bool {
+	if x != nil && x.Protected != nil {
+		return *x.Protected
+	}
+	return false
+}
+
 type Food struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Position      *Vector2D              `protobuf:"bytes,1,opt,name=position" json:"position,omitempty"`
@@ -871,36 +1229,28 @@ func (*PauseEvent) Descriptor() ([]byte, []int) {
 	return file_proto_galaxy_proto_rawDescGZIP(), []int{10}
 }
 
-type Operation struct {
+// A message from the server administrators to every player.
+type AnnouncementEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OperationType *OperationType         `protobuf:"varint,2,opt,name=operationType,enum=galaxy.OperationType" json:"operationType,omitempty"`
-	// Types that are valid to be assigned to OperationData:
-	//
-	//	*Operation_JoinOperation
-	//	*Operation_LeaveOperation
-	//	*Operation_MoveOperation
-	//	*Operation_EatPlayerOperation
-	//	*Operation_EatFoodOperation
-	//	*Operation_PauseOperation
-	OperationData isOperation_OperationData `protobuf_oneof:"operationData"`
+	Message       *string                `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Operation) Reset() {
-	*x = Operation{}
+func (x *AnnouncementEvent) Reset() {
+	*x = AnnouncementEvent{}
 	mi := &file_proto_galaxy_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Operation) String() string {
+func (x *AnnouncementEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Operation) ProtoMessage() {}
+func (*AnnouncementEvent) ProtoMessage() {}
 
-func (x *Operation) ProtoReflect() protoreflect.Message {
+func (x *AnnouncementEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_galaxy_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -912,145 +1262,1188 @@ func (x *Operation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
-func (*Operation) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnnouncementEvent.ProtoReflect.Descriptor instead.
+func (*AnnouncementEvent) Descriptor() ([]byte, []int) {
 	return file_proto_galaxy_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Operation) GetOperationType() OperationType {
-	if x != nil && x.OperationType != nil {
-		return *x.OperationType
+func (x *AnnouncementEvent) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
 	}
-	return OperationType_OpUnused
+	return ""
 }
 
-func (x *Operation) GetOperationData() isOperation_OperationData {
-	if x != nil {
-		return x.OperationData
-	}
-	return nil
+// Sent before the server closes every connection to shut down.
+type ShutdownEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        *string                `protobuf:"bytes,1,opt,name=reason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Operation) GetJoinOperation() *JoinOperation {
-	if x != nil {
-		if x, ok := x.OperationData.(*Operation_JoinOperation); ok {
-			return x.JoinOperation
-		}
-	}
-	return nil
+func (x *ShutdownEvent) Reset() {
+	*x = ShutdownEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Operation) GetLeaveOperation() *LeaveOperation {
-	if x != nil {
-		if x, ok := x.OperationData.(*Operation_LeaveOperation); ok {
-			return x.LeaveOperation
-		}
-	}
-	return nil
+func (x *ShutdownEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Operation) GetMoveOperation() *MoveOperation {
+func (*ShutdownEvent) ProtoMessage() {}
+
+func (x *ShutdownEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[12]
 	if x != nil {
-		if x, ok := x.OperationData.(*Operation_MoveOperation); ok {
-			return x.MoveOperation
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Operation) GetEatPlayerOperation() *EatPlayerOperation {
-	if x != nil {
-		if x, ok := x.OperationData.(*Operation_EatPlayerOperation); ok {
-			return x.EatPlayerOperation
-		}
-	}
-	return nil
+// Deprecated: Use ShutdownEvent.ProtoReflect.Descriptor instead.
+func (*ShutdownEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Operation) GetEatFoodOperation() *EatFoodOperation {
-	if x != nil {
-		if x, ok := x.OperationData.(*Operation_EatFoodOperation); ok {
-			return x.EatFoodOperation
-		}
+func (x *ShutdownEvent) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *Operation) GetPauseOperation() *PauseOperation {
-	if x != nil {
-		if x, ok := x.OperationData.(*Operation_PauseOperation); ok {
-			return x.PauseOperation
-		}
-	}
+// The spawn protection of a player is over.
+type ProtectionEndEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtectionEndEvent) Reset() {
+	*x = ProtectionEndEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtectionEndEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtectionEndEvent) ProtoMessage() {}
+
+func (x *ProtectionEndEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtectionEndEvent.ProtoReflect.Descriptor instead.
+func (*ProtectionEndEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProtectionEndEvent) GetPlayerID() []byte {
+	if x != nil {
+		return x.PlayerID
+	}
+	return nil
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	Score         *uint32                `protobuf:"varint,3,opt,name=score" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_proto_galaxy_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LeaderboardEntry) GetPlayerID() []byte {
+	if x != nil {
+		return x.PlayerID
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() uint32 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+// The biggest players in the world, sent every few seconds.
+type LeaderboardEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	// Position of the receiving player, starting at 1. Unset for spectators.
+	Rank          *uint32 `protobuf:"varint,2,opt,name=rank" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEvent) Reset() {
+	*x = LeaderboardEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEvent) ProtoMessage() {}
+
+func (x *LeaderboardEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEvent.ProtoReflect.Descriptor instead.
+func (*LeaderboardEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LeaderboardEvent) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *LeaderboardEvent) GetRank() uint32 {
+	if x != nil && x.Rank != nil {
+		return *x.Rank
+	}
+	return 0
+}
+
+type ChatEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	Username *string                `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	Message  *string                `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+	Channel  *ChatChannel           `protobuf:"varint,4,opt,name=channel,enum=galaxy.ChatChannel" json:"channel,omitempty"`
+	// The private game the message was sent in.
+	GameID *uint32 `protobuf:"varint,5,opt,name=gameID" json:"gameID,omitempty"`
+	// Only sent back to the sender, with the reason the message wasn't
+	// delivered.
+	Rejected      *string `protobuf:"bytes,6,opt,name=rejected" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatEvent) Reset() {
+	*x = ChatEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatEvent) ProtoMessage() {}
+
+func (x *ChatEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatEvent.ProtoReflect.Descriptor instead.
+func (*ChatEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChatEvent) GetPlayerID() []byte {
+	if x != nil {
+		return x.PlayerID
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetChannel() ChatChannel {
+	if x != nil && x.Channel != nil {
+		return *x.Channel
+	}
+	return ChatChannel_ChatWorld
+}
+
+func (x *ChatEvent) GetGameID() uint32 {
+	if x != nil && x.GameID != nil {
+		return *x.GameID
+	}
+	return 0
+}
+
+func (x *ChatEvent) GetRejected() string {
+	if x != nil && x.Rejected != nil {
+		return *x.Rejected
+	}
+	return ""
+}
+
+// A notable moment of the match, ready to be shown.
+type FeedEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Kind     *FeedKind              `protobuf:"varint,1,opt,name=kind,enum=galaxy.FeedKind" json:"kind,omitempty"`
+	Message  *string                `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	PlayerID []byte                 `protobuf:"bytes,3,opt,name=playerID" json:"playerID,omitempty"`
+	// The player eaten, in kills.
+	OtherPlayerID []byte `protobuf:"bytes,4,opt,name=otherPlayerID" json:"otherPlayerID,omitempty"`
+	// The radius reached, in milestones.
+	Radius        *uint32 `protobuf:"varint,5,opt,name=radius" json:"radius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedEvent) Reset() {
+	*x = FeedEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedEvent) ProtoMessage() {}
+
+func (x *FeedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedEvent.ProtoReflect.Descriptor instead.
+func (*FeedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FeedEvent) GetKind() FeedKind {
+	if x != nil && x.Kind != nil {
+		return *x.Kind
+	}
+	return FeedKind_FeedUnused
+}
+
+func (x *FeedEvent) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetPlayerID() []byte {
+	if x != nil {
+		return x.PlayerID
+	}
+	return nil
+}
+
+func (x *FeedEvent) GetOtherPlayerID() []byte {
+	if x != nil {
+		return x.OtherPlayerID
+	}
+	return nil
+}
+
+func (x *FeedEvent) GetRadius() uint32 {
+	if x != nil && x.Radius != nil {
+		return *x.Radius
+	}
+	return 0
+}
+
+// Tells a spectator which player it follows, none when it roams freely
+// or the server moves its camera.
+type SpectateEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Following     []byte                 `protobuf:"bytes,1,opt,name=following" json:"following,omitempty"`
+	Director      *bool                  `protobuf:"varint,2,opt,name=director" json:"director,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateEvent) Reset() {
+	*x = SpectateEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateEvent) ProtoMessage() {}
+
+func (x *SpectateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateEvent.ProtoReflect.Descriptor instead.
+func (*SpectateEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SpectateEvent) GetFollowing() []byte {
+	if x != nil {
+		return x.Following
+	}
+	return nil
+}
+
+func (x *SpectateEvent) GetDirector() bool {
+	if x != nil && x.Director != nil {
+		return *x.Director
+	}
+	return false
+}
+
+// Where the server points the camera of spectators that asked for it.
+type CameraTargetEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset when there is no one to look at.
+	PlayerID []byte        `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	Reason   *CameraReason `protobuf:"varint,2,opt,name=reason,enum=galaxy.CameraReason" json:"reason,omitempty"`
+	// The player being eaten or chased.
+	OtherPlayerID []byte `protobuf:"bytes,3,opt,name=otherPlayerID" json:"otherPlayerID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CameraTargetEvent) Reset() {
+	*x = CameraTargetEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CameraTargetEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CameraTargetEvent) ProtoMessage() {}
+
+func (x *CameraTargetEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CameraTargetEvent.ProtoReflect.Descriptor instead.
+func (*CameraTargetEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CameraTargetEvent) GetPlayerID() []byte {
+	if x != nil {
+		return x.PlayerID
+	}
+	return nil
+}
+
+func (x *CameraTargetEvent) GetReason() CameraReason {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return CameraReason_CameraNone
+}
+
+func (x *CameraTargetEvent) GetOtherPlayerID() []byte {
+	if x != nil {
+		return x.OtherPlayerID
+	}
+	return nil
+}
+
+// Sent to a player that has just been eaten, before it is removed.
+type GameOverEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	KillerID       []byte                 `protobuf:"bytes,1,opt,name=killerID" json:"killerID,omitempty"`
+	KillerUsername *string                `protobuf:"bytes,2,opt,name=killerUsername" json:"killerUsername,omitempty"`
+	FinalRadius    *uint32                `protobuf:"varint,3,opt,name=finalRadius" json:"finalRadius,omitempty"`
+	PeakRadius     *uint32                `protobuf:"varint,4,opt,name=peakRadius" json:"peakRadius,omitempty"`
+	Kills          *uint32                `protobuf:"varint,5,opt,name=kills" json:"kills,omitempty"`
+	FoodEaten      *uint32                `protobuf:"varint,6,opt,name=foodEaten" json:"foodEaten,omitempty"`
+	// Milliseconds since the current life started.
+	TimeAlive *int64 `protobuf:"varint,7,opt,name=timeAlive" json:"timeAlive,omitempty"`
+	// Position in the leaderboard when eaten, starting at 1.
+	Rank          *uint32 `protobuf:"varint,8,opt,name=rank" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameOverEvent) Reset() {
+	*x = GameOverEvent{}
+	mi := &file_proto_galaxy_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameOverEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameOverEvent) ProtoMessage() {}
+
+func (x *GameOverEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameOverEvent.ProtoReflect.Descriptor instead.
+func (*GameOverEvent) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GameOverEvent) GetKillerID() []byte {
+	if x != nil {
+		return x.KillerID
+	}
+	return nil
+}
+
+func (x *GameOverEvent) GetKillerUsername() string {
+	if x != nil && x.KillerUsername != nil {
+		return *x.KillerUsername
+	}
+	return ""
+}
+
+func (x *GameOverEvent) GetFinalRadius() uint32 {
+	if x != nil && x.FinalRadius != nil {
+		return *x.FinalRadius
+	}
+	return 0
+}
+
+func (x *GameOverEvent) GetPeakRadius() uint32 {
+	if x != nil && x.PeakRadius != nil {
+		return *x.PeakRadius
+	}
+	return 0
+}
+
+func (x *GameOverEvent) GetKills() uint32 {
+	if x != nil && x.Kills != nil {
+		return *x.Kills
+	}
+	return 0
+}
+
+func (x *GameOverEvent) GetFoodEaten() uint32 {
+	if x != nil && x.FoodEaten != nil {
+		return *x.FoodEaten
+	}
+	return 0
+}
+
+func (x *GameOverEvent) GetTimeAlive() int64 {
+	if x != nil && x.TimeAlive != nil {
+		return *x.TimeAlive
+	}
+	return 0
+}
+
+func (x *GameOverEvent) GetRank() uint32 {
+	if x != nil && x.Rank != nil {
+		return *x.Rank
+	}
+	return 0
+}
+
+type Operation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationType *OperationType         `protobuf:"varint,2,opt,name=operationType,enum=galaxy.OperationType" json:"operationType,omitempty"`
+	// Types that are valid to be assigned to OperationData:
+	//
+	//	*Operation_JoinOperation
+	//	*Operation_LeaveOperation
+	//	*Operation_MoveOperation
+	//	*Operation_EatPlayerOperation
+	//	*Operation_EatFoodOperation
+	//	*Operation_PauseOperation
+	//	*Operation_ReplayControlOperation
+	//	*Operation_RespawnOperation
+	//	*Operation_ChatOperation
+	//	*Operation_SpectateOperation
+	OperationData isOperation_OperationData `protobuf_oneof:"operationData"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_proto_galaxy_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Operation) GetOperationType() OperationType {
+	if x != nil && x.OperationType != nil {
+		return *x.OperationType
+	}
+	return OperationType_OpUnused
+}
+
+func (x *Operation) GetOperationData() isOperation_OperationData {
+	if x != nil {
+		return x.OperationData
+	}
+	return nil
+}
+
+func (x *Operation) GetJoinOperation() *JoinOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_JoinOperation); ok {
+			return x.JoinOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetLeaveOperation() *LeaveOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_LeaveOperation); ok {
+			return x.LeaveOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetMoveOperation() *MoveOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_MoveOperation); ok {
+			return x.MoveOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetEatPlayerOperation() *EatPlayerOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_EatPlayerOperation); ok {
+			return x.EatPlayerOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetEatFoodOperation() *EatFoodOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_EatFoodOperation); ok {
+			return x.EatFoodOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetPauseOperation() *PauseOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_PauseOperation); ok {
+			return x.PauseOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetReplayControlOperation() *ReplayControlOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_ReplayControlOperation); ok {
+			return x.ReplayControlOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRespawnOperation() *RespawnOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_RespawnOperation); ok {
+			return x.RespawnOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetChatOperation() *ChatOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_ChatOperation); ok {
+			return x.ChatOperation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetSpectateOperation() *SpectateOperation {
+	if x != nil {
+		if x, ok := x.OperationData.(*Operation_SpectateOperation); ok {
+			return x.SpectateOperation
+		}
+	}
+	return nil
+}
+
+type isOperation_OperationData interface {
+	isOperation_OperationData()
+}
+
+type Operation_JoinOperation struct {
+	JoinOperation *JoinOperation `protobuf:"bytes,3,opt,name=joinOperation,oneof"`
+}
+
+type Operation_LeaveOperation struct {
+	LeaveOperation *LeaveOperation `protobuf:"bytes,4,opt,name=leaveOperation,oneof"`
+}
+
+type Operation_MoveOperation struct {
+	MoveOperation *MoveOperation `protobuf:"bytes,5,opt,name=moveOperation,oneof"`
+}
+
+type Operation_EatPlayerOperation struct {
+	EatPlayerOperation *EatPlayerOperation `protobuf:"bytes,6,opt,name=eatPlayerOperation,oneof"`
+}
+
+type Operation_EatFoodOperation struct {
+	EatFoodOperation *EatFoodOperation `protobuf:"bytes,7,opt,name=eatFoodOperation,oneof"`
+}
+
+type Operation_PauseOperation struct {
+	PauseOperation *PauseOperation `protobuf:"bytes,8,opt,name=pauseOperation,oneof"`
+}
+
+type Operation_ReplayControlOperation struct {
+	ReplayControlOperation *ReplayControlOperation `protobuf:"bytes,9,opt,name=replayControlOperation,oneof"`
+}
+
+type Operation_RespawnOperation struct {
+	RespawnOperation *RespawnOperation `protobuf:"bytes,10,opt,name=respawnOperation,oneof"`
+}
+
+type Operation_ChatOperation struct {
+	ChatOperation *ChatOperation `protobuf:"bytes,11,opt,name=chatOperation,oneof"`
+}
+
+type Operation_SpectateOperation struct {
+	SpectateOperation *SpectateOperation `protobuf:"bytes,12,opt,name=spectateOperation,oneof"`
+}
+
+func (*Operation_JoinOperation) isOperation_OperationData() {}
+
+func (*Operation_LeaveOperation) isOperation_OperationData() {}
+
+func (*Operation_MoveOperation) isOperation_OperationData() {}
+
+func (*Operation_EatPlayerOperation) isOperation_OperationData() {}
+
+func (*Operation_EatFoodOperation) isOperation_OperationData() {}
+
+func (*Operation_PauseOperation) isOperation_OperationData() {}
+
+func (*Operation_ReplayControlOperation) isOperation_OperationData() {}
+
+func (*Operation_RespawnOperation) isOperation_OperationData() {}
+
+func (*Operation_ChatOperation) isOperation_OperationData() {}
+
+func (*Operation_SpectateOperation) isOperation_OperationData() {}
+
+type JoinOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerID      []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	Color         *uint32                `protobuf:"varint,3,opt,name=color" json:"color,omitempty"`
+	Skin          *string                `protobuf:"bytes,4,opt,name=skin" json:"skin,omitempty"`
+	GameID        *uint32                `protobuf:"varint,5,opt,name=gameID" json:"gameID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinOperation) Reset() {
+	*x = JoinOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinOperation) ProtoMessage() {}
+
+func (x *JoinOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinOperation.ProtoReflect.Descriptor instead.
+func (*JoinOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *JoinOperation) GetPlayerID() []byte {
+	if x != nil {
+		return x.PlayerID
+	}
 	return nil
 }
 
-type isOperation_OperationData interface {
-	isOperation_OperationData()
+func (x *JoinOperation) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
 }
 
-type Operation_JoinOperation struct {
-	JoinOperation *JoinOperation `protobuf:"bytes,3,opt,name=joinOperation,oneof"`
+func (x *JoinOperation) GetColor() uint32 {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return 0
 }
 
-type Operation_LeaveOperation struct {
-	LeaveOperation *LeaveOperation `protobuf:"bytes,4,opt,name=leaveOperation,oneof"`
+func (x *JoinOperation) GetSkin() string {
+	if x != nil && x.Skin != nil {
+		return *x.Skin
+	}
+	return ""
+}
+
+func (x *JoinOperation) GetGameID() uint32 {
+	if x != nil && x.GameID != nil {
+		return *x.GameID
+	}
+	return 0
+}
+
+type LeaveOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveOperation) Reset() {
+	*x = LeaveOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveOperation) ProtoMessage() {}
+
+func (x *LeaveOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveOperation.ProtoReflect.Descriptor instead.
+func (*LeaveOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{23}
+}
+
+type MoveOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      *Vector2D              `protobuf:"bytes,1,opt,name=position" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveOperation) Reset() {
+	*x = MoveOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveOperation) ProtoMessage() {}
+
+func (x *MoveOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveOperation.ProtoReflect.Descriptor instead.
+func (*MoveOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MoveOperation) GetPosition() *Vector2D {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type EatPlayerOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerEaten   []byte                 `protobuf:"bytes,1,opt,name=playerEaten" json:"playerEaten,omitempty"`
+	NewRadius     *uint32                `protobuf:"varint,2,opt,name=newRadius" json:"newRadius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EatPlayerOperation) Reset() {
+	*x = EatPlayerOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EatPlayerOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EatPlayerOperation) ProtoMessage() {}
+
+func (x *EatPlayerOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EatPlayerOperation.ProtoReflect.Descriptor instead.
+func (*EatPlayerOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *EatPlayerOperation) GetPlayerEaten() []byte {
+	if x != nil {
+		return x.PlayerEaten
+	}
+	return nil
+}
+
+func (x *EatPlayerOperation) GetNewRadius() uint32 {
+	if x != nil && x.NewRadius != nil {
+		return *x.NewRadius
+	}
+	return 0
+}
+
+type EatFoodOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FoodPosition  *Vector2D              `protobuf:"bytes,1,opt,name=foodPosition" json:"foodPosition,omitempty"`
+	NewRadius     *uint32                `protobuf:"varint,2,opt,name=newRadius" json:"newRadius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EatFoodOperation) Reset() {
+	*x = EatFoodOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EatFoodOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EatFoodOperation) ProtoMessage() {}
+
+func (x *EatFoodOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EatFoodOperation.ProtoReflect.Descriptor instead.
+func (*EatFoodOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EatFoodOperation) GetFoodPosition() *Vector2D {
+	if x != nil {
+		return x.FoodPosition
+	}
+	return nil
+}
+
+func (x *EatFoodOperation) GetNewRadius() uint32 {
+	if x != nil && x.NewRadius != nil {
+		return *x.NewRadius
+	}
+	return 0
+}
+
+type PauseOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseOperation) Reset() {
+	*x = PauseOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseOperation) ProtoMessage() {}
+
+func (x *PauseOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Operation_MoveOperation struct {
-	MoveOperation *MoveOperation `protobuf:"bytes,5,opt,name=moveOperation,oneof"`
+// Deprecated: Use PauseOperation.ProtoReflect.Descriptor instead.
+func (*PauseOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{27}
 }
 
-type Operation_EatPlayerOperation struct {
-	EatPlayerOperation *EatPlayerOperation `protobuf:"bytes,6,opt,name=eatPlayerOperation,oneof"`
+// Sent by an eaten player to enter the world again.
+type RespawnOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Operation_EatFoodOperation struct {
-	EatFoodOperation *EatFoodOperation `protobuf:"bytes,7,opt,name=eatFoodOperation,oneof"`
+func (x *RespawnOperation) Reset() {
+	*x = RespawnOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Operation_PauseOperation struct {
-	PauseOperation *PauseOperation `protobuf:"bytes,8,opt,name=pauseOperation,oneof"`
+func (x *RespawnOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Operation_JoinOperation) isOperation_OperationData() {}
-
-func (*Operation_LeaveOperation) isOperation_OperationData() {}
-
-func (*Operation_MoveOperation) isOperation_OperationData() {}
-
-func (*Operation_EatPlayerOperation) isOperation_OperationData() {}
+func (*RespawnOperation) ProtoMessage() {}
 
-func (*Operation_EatFoodOperation) isOperation_OperationData() {}
+func (x *RespawnOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Operation_PauseOperation) isOperation_OperationData() {}
+// Deprecated: Use RespawnOperation.ProtoReflect.Descriptor instead.
+func (*RespawnOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{28}
+}
 
-type JoinOperation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerID      []byte                 `protobuf:"bytes,1,opt,name=playerID" json:"playerID,omitempty"`
-	Username      *string                `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
-	Color         *uint32                `protobuf:"varint,3,opt,name=color" json:"color,omitempty"`
-	Skin          *string                `protobuf:"bytes,4,opt,name=skin" json:"skin,omitempty"`
-	GameID        *uint32                `protobuf:"varint,5,opt,name=gameID" json:"gameID,omitempty"`
+// Sent instead of a join to watch the world without a cell, or by
+// spectators to change the player they follow.
+type SpectateOperation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Player to follow, roams freely when unset.
+	Follow []byte `protobuf:"bytes,1,opt,name=follow" json:"follow,omitempty"`
+	// Game to watch in private servers.
+	GameID *uint32 `protobuf:"varint,2,opt,name=gameID" json:"gameID,omitempty"`
+	// Let the server move the camera to the interesting parts of the match.
+	Director      *bool `protobuf:"varint,3,opt,name=director" json:"director,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinOperation) Reset() {
-	*x = JoinOperation{}
-	mi := &file_proto_galaxy_proto_msgTypes[12]
+func (x *SpectateOperation) Reset() {
+	*x = SpectateOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinOperation) String() string {
+func (x *SpectateOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinOperation) ProtoMessage() {}
+func (*SpectateOperation) ProtoMessage() {}
 
-func (x *JoinOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_galaxy_proto_msgTypes[12]
+func (x *SpectateOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1061,67 +2454,57 @@ func (x *JoinOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinOperation.ProtoReflect.Descriptor instead.
-func (*JoinOperation) Descriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SpectateOperation.ProtoReflect.Descriptor instead.
+func (*SpectateOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *JoinOperation) GetPlayerID() []byte {
+func (x *SpectateOperation) GetFollow() []byte {
 	if x != nil {
-		return x.PlayerID
+		return x.Follow
 	}
 	return nil
 }
 
-func (x *JoinOperation) GetUsername() string {
-	if x != nil && x.Username != nil {
-		return *x.Username
-	}
-	return ""
-}
-
-func (x *JoinOperation) GetColor() uint32 {
-	if x != nil && x.Color != nil {
-		return *x.Color
+func (x *SpectateOperation) GetGameID() uint32 {
+	if x != nil && x.GameID != nil {
+		return *x.GameID
 	}
 	return 0
 }
 
-func (x *JoinOperation) GetSkin() string {
-	if x != nil && x.Skin != nil {
-		return *x.Skin
-	}
-	return ""
-}
-
-func (x *JoinOperation) GetGameID() uint32 {
-	if x != nil && x.GameID != nil {
-		return *x.GameID
+func (x *SpectateOperation) GetDirector() bool {
+	if x != nil && x.Director != nil {
+		return *x.Director
 	}
-	return 0
+	return false
 }
 
-type LeaveOperation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type ChatOperation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *string                `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Channel *ChatChannel           `protobuf:"varint,2,opt,name=channel,enum=galaxy.ChatChannel" json:"channel,omitempty"`
+	// Receiver of direct messages.
+	To            []byte `protobuf:"bytes,3,opt,name=to" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveOperation) Reset() {
-	*x = LeaveOperation{}
-	mi := &file_proto_galaxy_proto_msgTypes[13]
+func (x *ChatOperation) Reset() {
+	*x = ChatOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveOperation) String() string {
+func (x *ChatOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveOperation) ProtoMessage() {}
+func (*ChatOperation) ProtoMessage() {}
 
-func (x *LeaveOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_galaxy_proto_msgTypes[13]
+func (x *ChatOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1132,33 +2515,59 @@ func (x *LeaveOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveOperation.ProtoReflect.Descriptor instead.
-func (*LeaveOperation) Descriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ChatOperation.ProtoReflect.Descriptor instead.
+func (*ChatOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{30}
 }
 
-type MoveOperation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Position      *Vector2D              `protobuf:"bytes,1,opt,name=position" json:"position,omitempty"`
+func (x *ChatOperation) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ChatOperation) GetChannel() ChatChannel {
+	if x != nil && x.Channel != nil {
+		return *x.Channel
+	}
+	return ChatChannel_ChatWorld
+}
+
+func (x *ChatOperation) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Only understood by servers playing back a replay, every field is optional.
+type ReplayControlOperation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Playback speed, between 0.5 and 8.
+	Speed *float32 `protobuf:"fixed32,1,opt,name=speed" json:"speed,omitempty"`
+	// Milliseconds since the start of the replay to jump to.
+	Seek          *int64 `protobuf:"varint,2,opt,name=seek" json:"seek,omitempty"`
+	Paused        *bool  `protobuf:"varint,3,opt,name=paused" json:"paused,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MoveOperation) Reset() {
-	*x = MoveOperation{}
-	mi := &file_proto_galaxy_proto_msgTypes[14]
+func (x *ReplayControlOperation) Reset() {
+	*x = ReplayControlOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveOperation) String() string {
+func (x *ReplayControlOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveOperation) ProtoMessage() {}
+func (*ReplayControlOperation) ProtoMessage() {}
 
-func (x *MoveOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_galaxy_proto_msgTypes[14]
+func (x *ReplayControlOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1169,41 +2578,61 @@ func (x *MoveOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveOperation.ProtoReflect.Descriptor instead.
-func (*MoveOperation) Descriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ReplayControlOperation.ProtoReflect.Descriptor instead.
+func (*ReplayControlOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *MoveOperation) GetPosition() *Vector2D {
-	if x != nil {
-		return x.Position
+func (x *ReplayControlOperation) GetSpeed() float32 {
+	if x != nil && x.Speed != nil {
+		return *x.Speed
 	}
-	return nil
+	return 0
 }
 
-type EatPlayerOperation struct {
+func (x *ReplayControlOperation) GetSeek() int64 {
+	if x != nil && x.Seek != nil {
+		return *x.Seek
+	}
+	return 0
+}
+
+func (x *ReplayControlOperation) GetPaused() bool {
+	if x != nil && x.Paused != nil {
+		return *x.Paused
+	}
+	return false
+}
+
+// ReplayHeader is the first record of every replay file.
+type ReplayHeader struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerEaten   []byte                 `protobuf:"bytes,1,opt,name=playerEaten" json:"playerEaten,omitempty"`
-	NewRadius     *uint32                `protobuf:"varint,2,opt,name=newRadius" json:"newRadius,omitempty"`
+	Seed          *uint64                `protobuf:"varint,1,opt,name=seed" json:"seed,omitempty"`
+	WorldWidth    *uint32                `protobuf:"varint,2,opt,name=worldWidth" json:"worldWidth,omitempty"`
+	WorldHeight   *uint32                `protobuf:"varint,3,opt,name=worldHeight" json:"worldHeight,omitempty"`
+	PrivateServer *bool                  `protobuf:"varint,4,opt,name=privateServer" json:"privateServer,omitempty"`
+	GameID        *uint32                `protobuf:"varint,5,opt,name=gameID" json:"gameID,omitempty"`
+	// Unix time in nanoseconds when the file was opened.
+	StartTime     *int64 `protobuf:"varint,6,opt,name=startTime" json:"startTime,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EatPlayerOperation) Reset() {
-	*x = EatPlayerOperation{}
-	mi := &file_proto_galaxy_proto_msgTypes[15]
+func (x *ReplayHeader) Reset() {
+	*x = ReplayHeader{}
+	mi := &file_proto_galaxy_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EatPlayerOperation) String() string {
+func (x *ReplayHeader) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EatPlayerOperation) ProtoMessage() {}
+func (*ReplayHeader) ProtoMessage() {}
 
-func (x *EatPlayerOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_galaxy_proto_msgTypes[15]
+func (x *ReplayHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1214,48 +2643,76 @@ func (x *EatPlayerOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EatPlayerOperation.ProtoReflect.Descriptor instead.
-func (*EatPlayerOperation) Descriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ReplayHeader.ProtoReflect.Descriptor instead.
+func (*ReplayHeader) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *EatPlayerOperation) GetPlayerEaten() []byte {
-	if x != nil {
-		return x.PlayerEaten
+func (x *ReplayHeader) GetSeed() uint64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
 	}
-	return nil
+	return 0
 }
 
-func (x *EatPlayerOperation) GetNewRadius() uint32 {
-	if x != nil && x.NewRadius != nil {
-		return *x.NewRadius
+func (x *ReplayHeader) GetWorldWidth() uint32 {
+	if x != nil && x.WorldWidth != nil {
+		return *x.WorldWidth
 	}
 	return 0
 }
 
-type EatFoodOperation struct {
+func (x *ReplayHeader) GetWorldHeight() uint32 {
+	if x != nil && x.WorldHeight != nil {
+		return *x.WorldHeight
+	}
+	return 0
+}
+
+func (x *ReplayHeader) GetPrivateServer() bool {
+	if x != nil && x.PrivateServer != nil {
+		return *x.PrivateServer
+	}
+	return false
+}
+
+func (x *ReplayHeader) GetGameID() uint32 {
+	if x != nil && x.GameID != nil {
+		return *x.GameID
+	}
+	return 0
+}
+
+func (x *ReplayHeader) GetStartTime() int64 {
+	if x != nil && x.StartTime != nil {
+		return *x.StartTime
+	}
+	return 0
+}
+
+type ReplayOperation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FoodPosition  *Vector2D              `protobuf:"bytes,1,opt,name=foodPosition" json:"foodPosition,omitempty"`
-	NewRadius     *uint32                `protobuf:"varint,2,opt,name=newRadius" json:"newRadius,omitempty"`
+	ConnectionID  []byte                 `protobuf:"bytes,1,opt,name=connectionID" json:"connectionID,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,2,opt,name=operation" json:"operation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EatFoodOperation) Reset() {
-	*x = EatFoodOperation{}
-	mi := &file_proto_galaxy_proto_msgTypes[16]
+func (x *ReplayOperation) Reset() {
+	*x = ReplayOperation{}
+	mi := &file_proto_galaxy_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EatFoodOperation) String() string {
+func (x *ReplayOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EatFoodOperation) ProtoMessage() {}
+func (*ReplayOperation) ProtoMessage() {}
 
-func (x *EatFoodOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_galaxy_proto_msgTypes[16]
+func (x *ReplayOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1266,46 +2723,55 @@ func (x *EatFoodOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EatFoodOperation.ProtoReflect.Descriptor instead.
-func (*EatFoodOperation) Descriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ReplayOperation.ProtoReflect.Descriptor instead.
+func (*ReplayOperation) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *EatFoodOperation) GetFoodPosition() *Vector2D {
+func (x *ReplayOperation) GetConnectionID() []byte {
 	if x != nil {
-		return x.FoodPosition
+		return x.ConnectionID
 	}
 	return nil
 }
 
-func (x *EatFoodOperation) GetNewRadius() uint32 {
-	if x != nil && x.NewRadius != nil {
-		return *x.NewRadius
+func (x *ReplayOperation) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
 	}
-	return 0
+	return nil
 }
 
-type PauseOperation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// ReplayRecord is a single length-delimited entry of a replay file.
+type ReplayRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unix time in nanoseconds.
+	Timestamp *int64 `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Types that are valid to be assigned to RecordData:
+	//
+	//	*ReplayRecord_Header
+	//	*ReplayRecord_Operation
+	//	*ReplayRecord_Event
+	RecordData    isReplayRecord_RecordData `protobuf_oneof:"recordData"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PauseOperation) Reset() {
-	*x = PauseOperation{}
-	mi := &file_proto_galaxy_proto_msgTypes[17]
+func (x *ReplayRecord) Reset() {
+	*x = ReplayRecord{}
+	mi := &file_proto_galaxy_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PauseOperation) String() string {
+func (x *ReplayRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PauseOperation) ProtoMessage() {}
+func (*ReplayRecord) ProtoMessage() {}
 
-func (x *PauseOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_galaxy_proto_msgTypes[17]
+func (x *ReplayRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_galaxy_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1316,11 +2782,74 @@ func (x *PauseOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PauseOperation.ProtoReflect.Descriptor instead.
-func (*PauseOperation) Descriptor() ([]byte, []int) {
-	return file_proto_galaxy_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ReplayRecord.ProtoReflect.Descriptor instead.
+func (*ReplayRecord) Descriptor() ([]byte, []int) {
+	return file_proto_galaxy_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReplayRecord) GetTimestamp() int64 {
+	if x != nil && x.Timestamp != nil {
+		return *x.Timestamp
+	}
+	return 0
+}
+
+func (x *ReplayRecord) GetRecordData() isReplayRecord_RecordData {
+	if x != nil {
+		return x.RecordData
+	}
+	return nil
+}
+
+func (x *ReplayRecord) GetHeader() *ReplayHeader {
+	if x != nil {
+		if x, ok := x.RecordData.(*ReplayRecord_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *ReplayRecord) GetOperation() *ReplayOperation {
+	if x != nil {
+		if x, ok := x.RecordData.(*ReplayRecord_Operation); ok {
+			return x.Operation
+		}
+	}
+	return nil
+}
+
+func (x *ReplayRecord) GetEvent() *Event {
+	if x != nil {
+		if x, ok := x.RecordData.(*ReplayRecord_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+type isReplayRecord_RecordData interface {
+	isReplayRecord_RecordData()
+}
+
+type ReplayRecord_Header struct {
+	Header *ReplayHeader `protobuf:"bytes,2,opt,name=header,oneof"`
+}
+
+type ReplayRecord_Operation struct {
+	Operation *ReplayOperation `protobuf:"bytes,3,opt,name=operation,oneof"`
 }
 
+type ReplayRecord_Event struct {
+	Event *Event `protobuf:"bytes,4,opt,name=event,oneof"`
+}
+
+func (*ReplayRecord_Header) isReplayRecord_RecordData() {}
+
+func (*ReplayRecord_Operation) isReplayRecord_RecordData() {}
+
+func (*ReplayRecord_Event) isReplayRecord_RecordData() {}
+
 var File_proto_galaxy_proto protoreflect.FileDescriptor
 
 const file_proto_galaxy_proto_rawDesc = "" +
@@ -1328,7 +2857,7 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\x12proto/galaxy.proto\x12\x06galaxy\"&\n" +
 	"\bVector2D\x12\f\n" +
 	"\x01X\x18\x01 \x01(\rR\x01X\x12\f\n" +
-	"\x01Y\x18\x02 \x01(\rR\x01Y\"\xcc\x04\n" +
+	"\x01Y\x18\x02 \x01(\rR\x01Y\"\x9b\t\n" +
 	"\x05Event\x12/\n" +
 	"\teventType\x18\x01 \x01(\x0e2\x11.galaxy.EventTypeR\teventType\x12@\n" +
 	"\x0enewPlayerEvent\x18\x02 \x01(\v2\x16.galaxy.NewPlayerEventH\x00R\x0enewPlayerEvent\x12:\n" +
@@ -1340,21 +2869,33 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\tjoinEvent\x18\b \x01(\v2\x11.galaxy.JoinEventH\x00R\tjoinEvent\x124\n" +
 	"\n" +
 	"pauseEvent\x18\t \x01(\v2\x12.galaxy.PauseEventH\x00R\n" +
-	"pauseEventB\v\n" +
-	"\teventData\"\xb8\x01\n" +
+	"pauseEvent\x12I\n" +
+	"\x11announcementEvent\x18\n" +
+	" \x01(\v2\x19.galaxy.AnnouncementEventH\x00R\x11announcementEvent\x12=\n" +
+	"\rshutdownEvent\x18\v \x01(\v2\x15.galaxy.ShutdownEventH\x00R\rshutdownEvent\x12=\n" +
+	"\rgameOverEvent\x18\f \x01(\v2\x15.galaxy.GameOverEventH\x00R\rgameOverEvent\x12L\n" +
+	"\x12protectionEndEvent\x18\r \x01(\v2\x1a.galaxy.ProtectionEndEventH\x00R\x12protectionEndEvent\x12F\n" +
+	"\x10leaderboardEvent\x18\x0e \x01(\v2\x18.galaxy.LeaderboardEventH\x00R\x10leaderboardEvent\x121\n" +
+	"\tchatEvent\x18\x0f \x01(\v2\x11.galaxy.ChatEventH\x00R\tchatEvent\x121\n" +
+	"\tfeedEvent\x18\x10 \x01(\v2\x11.galaxy.FeedEventH\x00R\tfeedEvent\x12=\n" +
+	"\rspectateEvent\x18\x11 \x01(\v2\x15.galaxy.SpectateEventH\x00R\rspectateEvent\x12I\n" +
+	"\x11cameraTargetEvent\x18\x12 \x01(\v2\x19.galaxy.CameraTargetEventH\x00R\x11cameraTargetEventB\v\n" +
+	"\teventData\"\xd6\x01\n" +
 	"\x0eNewPlayerEvent\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\fR\bplayerID\x12,\n" +
 	"\bposition\x18\x02 \x01(\v2\x10.galaxy.Vector2DR\bposition\x12\x16\n" +
 	"\x06radius\x18\x03 \x01(\rR\x06radius\x12\x14\n" +
 	"\x05color\x18\x04 \x01(\rR\x05color\x12\x12\n" +
 	"\x04skin\x18\x05 \x01(\tR\x04skin\x12\x1a\n" +
-	"\busername\x18\x06 \x01(\tR\busername\"\x97\x01\n" +
+	"\busername\x18\x06 \x01(\tR\busername\x12\x1c\n" +
+	"\tprotected\x18\a \x01(\bR\tprotected\"\xb5\x01\n" +
 	"\tJoinEvent\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\fR\bplayerID\x12,\n" +
 	"\bposition\x18\x02 \x01(\v2\x10.galaxy.Vector2DR\bposition\x12\x16\n" +
 	"\x06radius\x18\x03 \x01(\rR\x06radius\x12\x14\n" +
 	"\x05color\x18\x04 \x01(\rR\x05color\x12\x12\n" +
-	"\x04skin\x18\x05 \x01(\tR\x04skin\"J\n" +
+	"\x04skin\x18\x05 \x01(\tR\x04skin\x12\x1c\n" +
+	"\tprotected\x18\x06 \x01(\bR\tprotected\"J\n" +
 	"\x04Food\x12,\n" +
 	"\bposition\x18\x01 \x01(\v2\x10.galaxy.Vector2DR\bposition\x12\x14\n" +
 	"\x05color\x18\x02 \x01(\rR\x05color\"0\n" +
@@ -1371,7 +2912,51 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\x12DestroyPlayerEvent\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\fR\bplayerID\"\f\n" +
 	"\n" +
-	"PauseEvent\"\xf1\x03\n" +
+	"PauseEvent\"-\n" +
+	"\x11AnnouncementEvent\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"'\n" +
+	"\rShutdownEvent\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"0\n" +
+	"\x12ProtectionEndEvent\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\fR\bplayerID\"`\n" +
+	"\x10LeaderboardEntry\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\fR\bplayerID\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\rR\x05score\"Z\n" +
+	"\x10LeaderboardEvent\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.galaxy.LeaderboardEntryR\aentries\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\rR\x04rank\"\xc0\x01\n" +
+	"\tChatEvent\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\fR\bplayerID\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12-\n" +
+	"\achannel\x18\x04 \x01(\x0e2\x13.galaxy.ChatChannelR\achannel\x12\x16\n" +
+	"\x06gameID\x18\x05 \x01(\rR\x06gameID\x12\x1a\n" +
+	"\brejected\x18\x06 \x01(\tR\brejected\"\xa5\x01\n" +
+	"\tFeedEvent\x12$\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x10.galaxy.FeedKindR\x04kind\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bplayerID\x18\x03 \x01(\fR\bplayerID\x12$\n" +
+	"\rotherPlayerID\x18\x04 \x01(\fR\rotherPlayerID\x12\x16\n" +
+	"\x06radius\x18\x05 \x01(\rR\x06radius\"I\n" +
+	"\rSpectateEvent\x12\x1c\n" +
+	"\tfollowing\x18\x01 \x01(\fR\tfollowing\x12\x1a\n" +
+	"\bdirector\x18\x02 \x01(\bR\bdirector\"\x83\x01\n" +
+	"\x11CameraTargetEvent\x12\x1a\n" +
+	"\bplayerID\x18\x01 \x01(\fR\bplayerID\x12,\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x14.galaxy.CameraReasonR\x06reason\x12$\n" +
+	"\rotherPlayerID\x18\x03 \x01(\fR\rotherPlayerID\"\xfb\x01\n" +
+	"\rGameOverEvent\x12\x1a\n" +
+	"\bkillerID\x18\x01 \x01(\fR\bkillerID\x12&\n" +
+	"\x0ekillerUsername\x18\x02 \x01(\tR\x0ekillerUsername\x12 \n" +
+	"\vfinalRadius\x18\x03 \x01(\rR\vfinalRadius\x12\x1e\n" +
+	"\n" +
+	"peakRadius\x18\x04 \x01(\rR\n" +
+	"peakRadius\x12\x14\n" +
+	"\x05kills\x18\x05 \x01(\rR\x05kills\x12\x1c\n" +
+	"\tfoodEaten\x18\x06 \x01(\rR\tfoodEaten\x12\x1c\n" +
+	"\ttimeAlive\x18\a \x01(\x03R\ttimeAlive\x12\x12\n" +
+	"\x04rank\x18\b \x01(\rR\x04rank\"\x9d\x06\n" +
 	"\tOperation\x12;\n" +
 	"\roperationType\x18\x02 \x01(\x0e2\x15.galaxy.OperationTypeR\roperationType\x12=\n" +
 	"\rjoinOperation\x18\x03 \x01(\v2\x15.galaxy.JoinOperationH\x00R\rjoinOperation\x12@\n" +
@@ -1379,7 +2964,12 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\rmoveOperation\x18\x05 \x01(\v2\x15.galaxy.MoveOperationH\x00R\rmoveOperation\x12L\n" +
 	"\x12eatPlayerOperation\x18\x06 \x01(\v2\x1a.galaxy.EatPlayerOperationH\x00R\x12eatPlayerOperation\x12F\n" +
 	"\x10eatFoodOperation\x18\a \x01(\v2\x18.galaxy.EatFoodOperationH\x00R\x10eatFoodOperation\x12@\n" +
-	"\x0epauseOperation\x18\b \x01(\v2\x16.galaxy.PauseOperationH\x00R\x0epauseOperationB\x0f\n" +
+	"\x0epauseOperation\x18\b \x01(\v2\x16.galaxy.PauseOperationH\x00R\x0epauseOperation\x12X\n" +
+	"\x16replayControlOperation\x18\t \x01(\v2\x1e.galaxy.ReplayControlOperationH\x00R\x16replayControlOperation\x12F\n" +
+	"\x10respawnOperation\x18\n" +
+	" \x01(\v2\x18.galaxy.RespawnOperationH\x00R\x10respawnOperation\x12=\n" +
+	"\rchatOperation\x18\v \x01(\v2\x15.galaxy.ChatOperationH\x00R\rchatOperation\x12I\n" +
+	"\x11spectateOperation\x18\f \x01(\v2\x19.galaxy.SpectateOperationH\x00R\x11spectateOperationB\x0f\n" +
 	"\roperationData\"\x89\x01\n" +
 	"\rJoinOperation\x12\x1a\n" +
 	"\bplayerID\x18\x01 \x01(\fR\bplayerID\x12\x1a\n" +
@@ -1396,7 +2986,39 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\x10EatFoodOperation\x124\n" +
 	"\ffoodPosition\x18\x01 \x01(\v2\x10.galaxy.Vector2DR\ffoodPosition\x12\x1c\n" +
 	"\tnewRadius\x18\x02 \x01(\rR\tnewRadius\"\x10\n" +
-	"\x0ePauseOperation*\x9e\x01\n" +
+	"\x0ePauseOperation\"\x12\n" +
+	"\x10RespawnOperation\"_\n" +
+	"\x11SpectateOperation\x12\x16\n" +
+	"\x06follow\x18\x01 \x01(\fR\x06follow\x12\x16\n" +
+	"\x06gameID\x18\x02 \x01(\rR\x06gameID\x12\x1a\n" +
+	"\bdirector\x18\x03 \x01(\bR\bdirector\"h\n" +
+	"\rChatOperation\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12-\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x13.galaxy.ChatChannelR\achannel\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\fR\x02to\"Z\n" +
+	"\x16ReplayControlOperation\x12\x14\n" +
+	"\x05speed\x18\x01 \x01(\x02R\x05speed\x12\x12\n" +
+	"\x04seek\x18\x02 \x01(\x03R\x04seek\x12\x16\n" +
+	"\x06paused\x18\x03 \x01(\bR\x06paused\"\xc0\x01\n" +
+	"\fReplayHeader\x12\x12\n" +
+	"\x04seed\x18\x01 \x01(\x04R\x04seed\x12\x1e\n" +
+	"\n" +
+	"worldWidth\x18\x02 \x01(\rR\n" +
+	"worldWidth\x12 \n" +
+	"\vworldHeight\x18\x03 \x01(\rR\vworldHeight\x12$\n" +
+	"\rprivateServer\x18\x04 \x01(\bR\rprivateServer\x12\x16\n" +
+	"\x06gameID\x18\x05 \x01(\rR\x06gameID\x12\x1c\n" +
+	"\tstartTime\x18\x06 \x01(\x03R\tstartTime\"f\n" +
+	"\x0fReplayOperation\x12\"\n" +
+	"\fconnectionID\x18\x01 \x01(\fR\fconnectionID\x12/\n" +
+	"\toperation\x18\x02 \x01(\v2\x11.galaxy.OperationR\toperation\"\xca\x01\n" +
+	"\fReplayRecord\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12.\n" +
+	"\x06header\x18\x02 \x01(\v2\x14.galaxy.ReplayHeaderH\x00R\x06header\x127\n" +
+	"\toperation\x18\x03 \x01(\v2\x17.galaxy.ReplayOperationH\x00R\toperation\x12%\n" +
+	"\x05event\x18\x04 \x01(\v2\r.galaxy.EventH\x00R\x05eventB\f\n" +
+	"\n" +
+	"recordData*\xb6\x02\n" +
 	"\tEventType\x12\f\n" +
 	"\bEvUnused\x10\x00\x12\r\n" +
 	"\tEvNewFood\x10\x01\x12\x0f\n" +
@@ -1407,7 +3029,39 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\x0fEvDestroyPlayer\x10\x06\x12\n" +
 	"\n" +
 	"\x06EvJoin\x10\a\x12\v\n" +
-	"\aEvPause\x10\b*o\n" +
+	"\aEvPause\x10\b\x12\x12\n" +
+	"\x0eEvAnnouncement\x10\t\x12\x0e\n" +
+	"\n" +
+	"EvShutdown\x10\n" +
+	"\x12\x0e\n" +
+	"\n" +
+	"EvGameOver\x10\v\x12\x13\n" +
+	"\x0fEvProtectionEnd\x10\f\x12\x11\n" +
+	"\rEvLeaderboard\x10\r\x12\n" +
+	"\n" +
+	"\x06EvChat\x10\x0e\x12\n" +
+	"\n" +
+	"\x06EvFeed\x10\x0f\x12\x0e\n" +
+	"\n" +
+	"EvSpectate\x10\x10\x12\x12\n" +
+	"\x0eEvCameraTarget\x10\x11*,\n" +
+	"\vChatChannel\x12\r\n" +
+	"\tChatWorld\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ChatDirect\x10\x01*`\n" +
+	"\bFeedKind\x12\x0e\n" +
+	"\n" +
+	"FeedUnused\x10\x00\x12\f\n" +
+	"\bFeedKill\x10\x01\x12\x10\n" +
+	"\fFeedBotEaten\x10\x02\x12\x11\n" +
+	"\rFeedNewLeader\x10\x03\x12\x11\n" +
+	"\rFeedMilestone\x10\x04*P\n" +
+	"\fCameraReason\x12\x0e\n" +
+	"\n" +
+	"CameraNone\x10\x00\x12\x10\n" +
+	"\fCameraLeader\x10\x01\x12\r\n" +
+	"\tCameraEat\x10\x02\x12\x0f\n" +
+	"\vCameraChase\x10\x03*\xaf\x01\n" +
 	"\rOperationType\x12\f\n" +
 	"\bOpUnused\x10\x00\x12\n" +
 	"\n" +
@@ -1417,7 +3071,14 @@ const file_proto_galaxy_proto_rawDesc = "" +
 	"\x06OpMove\x10\x03\x12\x0f\n" +
 	"\vOpEatPlayer\x10\x04\x12\r\n" +
 	"\tOpEatFood\x10\x05\x12\v\n" +
-	"\aOpPause\x10\x06B\tZ\a./protob\beditionsp\xe8\a"
+	"\aOpPause\x10\x06\x12\x13\n" +
+	"\x0fOpReplayControl\x10\a\x12\r\n" +
+	"\tOpRespawn\x10\b\x12\n" +
+	"\n" +
+	"\x06OpChat\x10\t\x12\x0e\n" +
+	"\n" +
+	"OpSpectate\x10\n" +
+	"B\tZ\a./protob\beditionsp\xe8\a"
 
 var (
 	file_proto_galaxy_proto_rawDescOnce sync.Once
@@ -1431,60 +3092,102 @@ func file_proto_galaxy_proto_rawDescGZIP() []byte {
 	return file_proto_galaxy_proto_rawDescData
 }
 
-var file_proto_galaxy_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_galaxy_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_galaxy_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_galaxy_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_proto_galaxy_proto_goTypes = []any{
-	(EventType)(0),             // 0: galaxy.EventType
-	(OperationType)(0),         // 1: galaxy.OperationType
-	(*Vector2D)(nil),           // 2: galaxy.Vector2D
-	(*Event)(nil),              // 3: galaxy.Event
-	(*NewPlayerEvent)(nil),     // 4: galaxy.NewPlayerEvent
-	(*JoinEvent)(nil),          // 5: galaxy.JoinEvent
-	(*Food)(nil),               // 6: galaxy.Food
-	(*NewFoodEvent)(nil),       // 7: galaxy.NewFoodEvent
-	(*PlayerMoveEvent)(nil),    // 8: galaxy.PlayerMoveEvent
-	(*PlayerGrowEvent)(nil),    // 9: galaxy.PlayerGrowEvent
-	(*DestroyFoodEvent)(nil),   // 10: galaxy.DestroyFoodEvent
-	(*DestroyPlayerEvent)(nil), // 11: galaxy.DestroyPlayerEvent
-	(*PauseEvent)(nil),         // 12: galaxy.PauseEvent
-	(*Operation)(nil),          // 13: galaxy.Operation
-	(*JoinOperation)(nil),      // 14: galaxy.JoinOperation
-	(*LeaveOperation)(nil),     // 15: galaxy.LeaveOperation
-	(*MoveOperation)(nil),      // 16: galaxy.MoveOperation
-	(*EatPlayerOperation)(nil), // 17: galaxy.EatPlayerOperation
-	(*EatFoodOperation)(nil),   // 18: galaxy.EatFoodOperation
-	(*PauseOperation)(nil),     // 19: galaxy.PauseOperation
+	(EventType)(0),                 // 0: galaxy.EventType
+	(ChatChannel)(0),               // 1: galaxy.ChatChannel
+	(FeedKind)(0),                  // 2: galaxy.FeedKind
+	(CameraReason)(0),              // 3: galaxy.CameraReason
+	(OperationType)(0),             // 4: galaxy.OperationType
+	(*Vector2D)(nil),               // 5: galaxy.Vector2D
+	(*Event)(nil),                  // 6: galaxy.Event
+	(*NewPlayerEvent)(nil),         // 7: galaxy.NewPlayerEvent
+	(*JoinEvent)(nil),              // 8: galaxy.JoinEvent
+	(*Food)(nil),                   // 9: galaxy.Food
+	(*NewFoodEvent)(nil),           // 10: galaxy.NewFoodEvent
+	(*PlayerMoveEvent)(nil),        // 11: galaxy.PlayerMoveEvent
+	(*PlayerGrowEvent)(nil),        // 12: galaxy.PlayerGrowEvent
+	(*DestroyFoodEvent)(nil),       // 13: galaxy.DestroyFoodEvent
+	(*DestroyPlayerEvent)(nil),     // 14: galaxy.DestroyPlayerEvent
+	(*PauseEvent)(nil),             // 15: galaxy.PauseEvent
+	(*AnnouncementEvent)(nil),      // 16: galaxy.AnnouncementEvent
+	(*ShutdownEvent)(nil),          // 17: galaxy.ShutdownEvent
+	(*ProtectionEndEvent)(nil),     // 18: galaxy.ProtectionEndEvent
+	(*LeaderboardEntry)(nil),       // 19: galaxy.LeaderboardEntry
+	(*LeaderboardEvent)(nil),       // 20: galaxy.LeaderboardEvent
+	(*ChatEvent)(nil),              // 21: galaxy.ChatEvent
+	(*FeedEvent)(nil),              // 22: galaxy.FeedEvent
+	(*SpectateEvent)(nil),          // 23: galaxy.SpectateEvent
+	(*CameraTargetEvent)(nil),      // 24: galaxy.CameraTargetEvent
+	(*GameOverEvent)(nil),          // 25: galaxy.GameOverEvent
+	(*Operation)(nil),              // 26: galaxy.Operation
+	(*JoinOperation)(nil),          // 27: galaxy.JoinOperation
+	(*LeaveOperation)(nil),         // 28: galaxy.LeaveOperation
+	(*MoveOperation)(nil),          // 29: galaxy.MoveOperation
+	(*EatPlayerOperation)(nil),     // 30: galaxy.EatPlayerOperation
+	(*EatFoodOperation)(nil),       // 31: galaxy.EatFoodOperation
+	(*PauseOperation)(nil),         // 32: galaxy.PauseOperation
+	(*RespawnOperation)(nil),       // 33: galaxy.RespawnOperation
+	(*SpectateOperation)(nil),      // 34: galaxy.SpectateOperation
+	(*ChatOperation)(nil),          // 35: galaxy.ChatOperation
+	(*ReplayControlOperation)(nil), // 36: galaxy.ReplayControlOperation
+	(*ReplayHeader)(nil),           // 37: galaxy.ReplayHeader
+	(*ReplayOperation)(nil),        // 38: galaxy.ReplayOperation
+	(*ReplayRecord)(nil),           // 39: galaxy.ReplayRecord
 }
 var file_proto_galaxy_proto_depIdxs = []int32{
 	0,  // 0: galaxy.Event.eventType:type_name -> galaxy.EventType
-	4,  // 1: galaxy.Event.newPlayerEvent:type_name -> galaxy.NewPlayerEvent
-	7,  // 2: galaxy.Event.newFoodEvent:type_name -> galaxy.NewFoodEvent
-	8,  // 3: galaxy.Event.playerMoveEvent:type_name -> galaxy.PlayerMoveEvent
-	9,  // 4: galaxy.Event.playerGrowEvent:type_name -> galaxy.PlayerGrowEvent
-	10, // 5: galaxy.Event.destroyFoodEvent:type_name -> galaxy.DestroyFoodEvent
-	11, // 6: galaxy.Event.destroyPlayerEvent:type_name -> galaxy.DestroyPlayerEvent
-	5,  // 7: galaxy.Event.joinEvent:type_name -> galaxy.JoinEvent
-	12, // 8: galaxy.Event.pauseEvent:type_name -> galaxy.PauseEvent
-	2,  // 9: galaxy.NewPlayerEvent.position:type_name -> galaxy.Vector2D
-	2,  // 10: galaxy.JoinEvent.position:type_name -> galaxy.Vector2D
-	2,  // 11: galaxy.Food.position:type_name -> galaxy.Vector2D
-	6,  // 12: galaxy.NewFoodEvent.food:type_name -> galaxy.Food
-	2,  // 13: galaxy.PlayerMoveEvent.position:type_name -> galaxy.Vector2D
-	2,  // 14: galaxy.DestroyFoodEvent.position:type_name -> galaxy.Vector2D
-	1,  // 15: galaxy.Operation.operationType:type_name -> galaxy.OperationType
-	14, // 16: galaxy.Operation.joinOperation:type_name -> galaxy.JoinOperation
-	15, // 17: galaxy.Operation.leaveOperation:type_name -> galaxy.LeaveOperation
-	16, // 18: galaxy.Operation.moveOperation:type_name -> galaxy.MoveOperation
-	17, // 19: galaxy.Operation.eatPlayerOperation:type_name -> galaxy.EatPlayerOperation
-	18, // 20: galaxy.Operation.eatFoodOperation:type_name -> galaxy.EatFoodOperation
-	19, // 21: galaxy.Operation.pauseOperation:type_name -> galaxy.PauseOperation
-	2,  // 22: galaxy.MoveOperation.position:type_name -> galaxy.Vector2D
-	2,  // 23: galaxy.EatFoodOperation.foodPosition:type_name -> galaxy.Vector2D
-	24, // [24:24] is the sub-list for method output_type
-	24, // [24:24] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	7,  // 1: galaxy.Event.newPlayerEvent:type_name -> galaxy.NewPlayerEvent
+	10, // 2: galaxy.Event.newFoodEvent:type_name -> galaxy.NewFoodEvent
+	11, // 3: galaxy.Event.playerMoveEvent:type_name -> galaxy.PlayerMoveEvent
+	12, // 4: galaxy.Event.playerGrowEvent:type_name -> galaxy.PlayerGrowEvent
+	13, // 5: galaxy.Event.destroyFoodEvent:type_name -> galaxy.DestroyFoodEvent
+	14, // 6: galaxy.Event.destroyPlayerEvent:type_name -> galaxy.DestroyPlayerEvent
+	8,  // 7: galaxy.Event.joinEvent:type_name -> galaxy.JoinEvent
+	15, // 8: galaxy.Event.pauseEvent:type_name -> galaxy.PauseEvent
+	16, // 9: galaxy.Event.announcementEvent:type_name -> galaxy.AnnouncementEvent
+	17, // 10: galaxy.Event.shutdownEvent:type_name -> galaxy.ShutdownEvent
+	25, // 11: galaxy.Event.gameOverEvent:type_name -> galaxy.GameOverEvent
+	18, // 12: galaxy.Event.protectionEndEvent:type_name -> galaxy.ProtectionEndEvent
+	20, // 13: galaxy.Event.leaderboardEvent:type_name -> galaxy.LeaderboardEvent
+	21, // 14: galaxy.Event.chatEvent:type_name -> galaxy.ChatEvent
+	22, // 15: galaxy.Event.feedEvent:type_name -> galaxy.FeedEvent
+	23, // 16: galaxy.Event.spectateEvent:type_name -> galaxy.SpectateEvent
+	24, // 17: galaxy.Event.cameraTargetEvent:type_name -> galaxy.CameraTargetEvent
+	5,  // 18: galaxy.NewPlayerEvent.position:type_name -> galaxy.Vector2D
+	5,  // 19: galaxy.JoinEvent.position:type_name -> galaxy.Vector2D
+	5,  // 20: galaxy.Food.position:type_name -> galaxy.Vector2D
+	9,  // 21: galaxy.NewFoodEvent.food:type_name -> galaxy.Food
+	5,  // 22: galaxy.PlayerMoveEvent.position:type_name -> galaxy.Vector2D
+	5,  // 23: galaxy.DestroyFoodEvent.position:type_name -> galaxy.Vector2D
+	19, // 24: galaxy.LeaderboardEvent.entries:type_name -> galaxy.LeaderboardEntry
+	1,  // 25: galaxy.ChatEvent.channel:type_name -> galaxy.ChatChannel
+	2,  // 26: galaxy.FeedEvent.kind:type_name -> galaxy.FeedKind
+	3,  // 27: galaxy.CameraTargetEvent.reason:type_name -> galaxy.CameraReason
+	4,  // 28: galaxy.Operation.operationType:type_name -> galaxy.OperationType
+	27, // 29: galaxy.Operation.joinOperation:type_name -> galaxy.JoinOperation
+	28, // 30: galaxy.Operation.leaveOperation:type_name -> galaxy.LeaveOperation
+	29, // 31: galaxy.Operation.moveOperation:type_name -> galaxy.MoveOperation
+	30, // 32: galaxy.Operation.eatPlayerOperation:type_name -> galaxy.EatPlayerOperation
+	31, // 33: galaxy.Operation.eatFoodOperation:type_name -> galaxy.EatFoodOperation
+	32, // 34: galaxy.Operation.pauseOperation:type_name -> galaxy.PauseOperation
+	36, // 35: galaxy.Operation.replayControlOperation:type_name -> galaxy.ReplayControlOperation
+	33, // 36: galaxy.Operation.respawnOperation:type_name -> galaxy.RespawnOperation
+	35, // 37: galaxy.Operation.chatOperation:type_name -> galaxy.ChatOperation
+	34, // 38: galaxy.Operation.spectateOperation:type_name -> galaxy.SpectateOperation
+	5,  // 39: galaxy.MoveOperation.position:type_name -> galaxy.Vector2D
+	5,  // 40: galaxy.EatFoodOperation.foodPosition:type_name -> galaxy.Vector2D
+	1,  // 41: galaxy.ChatOperation.channel:type_name -> galaxy.ChatChannel
+	26, // 42: galaxy.ReplayOperation.operation:type_name -> galaxy.Operation
+	37, // 43: galaxy.ReplayRecord.header:type_name -> galaxy.ReplayHeader
+	38, // 44: galaxy.ReplayRecord.operation:type_name -> galaxy.ReplayOperation
+	6,  // 45: galaxy.ReplayRecord.event:type_name -> galaxy.Event
+	46, // [46:46] is the sub-list for method output_type
+	46, // [46:46] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_proto_galaxy_proto_init() }
@@ -1501,22 +3204,40 @@ func file_proto_galaxy_proto_init() {
 		(*Event_DestroyPlayerEvent)(nil),
 		(*Event_JoinEvent)(nil),
 		(*Event_PauseEvent)(nil),
-	}
-	file_proto_galaxy_proto_msgTypes[11].OneofWrappers = []any{
+		(*Event_AnnouncementEvent)(nil),
+		(*Event_ShutdownEvent)(nil),
+		(*Event_GameOverEvent)(nil),
+		(*Event_ProtectionEndEvent)(nil),
+		(*Event_LeaderboardEvent)(nil),
+		(*Event_ChatEvent)(nil),
+		(*Event_FeedEvent)(nil),
+		(*Event_SpectateEvent)(nil),
+		(*Event_CameraTargetEvent)(nil),
+	}
+	file_proto_galaxy_proto_msgTypes[21].OneofWrappers = []any{
 		(*Operation_JoinOperation)(nil),
 		(*Operation_LeaveOperation)(nil),
 		(*Operation_MoveOperation)(nil),
 		(*Operation_EatPlayerOperation)(nil),
 		(*Operation_EatFoodOperation)(nil),
 		(*Operation_PauseOperation)(nil),
+		(*Operation_ReplayControlOperation)(nil),
+		(*Operation_RespawnOperation)(nil),
+		(*Operation_ChatOperation)(nil),
+		(*Operation_SpectateOperation)(nil),
+	}
+	file_proto_galaxy_proto_msgTypes[34].OneofWrappers = []any{
+		(*ReplayRecord_Header)(nil),
+		(*ReplayRecord_Operation)(nil),
+		(*ReplayRecord_Event)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_galaxy_proto_rawDesc), len(file_proto_galaxy_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   18,
+			NumEnums:      5,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -1,7 +1,7 @@
 package websockets
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 
 	"galaxy.io/server/galaxy"
@@ -22,11 +22,18 @@ func (c *Client) SendEvent(event *pb.Event) error {
 }
 
 func (c *Client) Close() {
-	log.Printf("closing connection")
+	slog.Debug("closing connection")
 	c.conn.Close()
 }
 
-type WebsocketFactory struct{}
+func (c *Client) Done() <-chan struct{} {
+	return c.conn.closed
+}
+
+type WebsocketFactory struct {
+	// Largest message accepted from a client, in bytes.
+	MaxMessageSize int64
+}
 
 func (f *WebsocketFactory) NewConnection(
 	w http.ResponseWriter,
@@ -37,13 +44,13 @@ func (f *WebsocketFactory) NewConnection(
 		operation := &pb.Operation{}
 		err := proto.Unmarshal(data, operation)
 		if err != nil {
-			log.Printf("Error unmarshing operation: %v", err)
+			slog.Warn("error unmarshaling operation", "err", err)
 			return
 		}
 		operationHandler(operation)
 	}
 
-	conn, err := Upgrade(w, r, handler)
+	conn, err := Upgrade(w, r, handler, f.MaxMessageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -2,11 +2,12 @@ package websockets
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"sync"
 	"time"
 
+	"galaxy.io/server/logging"
 	ws "github.com/gorilla/websocket"
 )
 
@@ -14,9 +15,14 @@ const (
 	writeWait      = 0
 	pongWait       = 60 * time.Second
 	pingPeriod     = (pongWait * 9) / 10
-	maxMessageSize = 512
+
+	closeGracePeriod = time.Second
 )
 
+// closedSendSampler limits the logs of sends to closed connections, every
+// broadcast hits them until the player is removed.
+var closedSendSampler = logging.NewSampler(100)
+
 // MessageHandler defines a function that processes binary messaeges
 type MessageHandler func([]byte)
 
@@ -32,6 +38,8 @@ type Connection struct {
 	handler   MessageHandler
 	closeOnce sync.Once
 	closed    chan struct{}
+
+	maxMessageSize int64
 }
 
 var upgrader = ws.Upgrader{
@@ -41,7 +49,7 @@ var upgrader = ws.Upgrader{
 	CheckOrigin: func(r *http.Request) bool { return true },
 }
 
-func Upgrade(w http.ResponseWriter, r *http.Request, handler MessageHandler) (*Connection, error) {
+func Upgrade(w http.ResponseWriter, r *http.Request, handler MessageHandler, maxMessageSize int64) (*Connection, error) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 
 	if err != nil {
@@ -53,19 +61,29 @@ func Upgrade(w http.ResponseWriter, r *http.Request, handler MessageHandler) (*C
 		send:    make(chan []byte, 2048),
 		handler: handler,
 		closed:  make(chan struct{}),
+
+		maxMessageSize: maxMessageSize,
 	}
 
+	connections.Store(c, struct{}{})
+
 	go c.readPump()
 	go c.writePump()
 
 	return c, nil
 }
 
+// Close stops accepting messages and lets the write pump flush the queued
+// ones followed by a close frame. The socket is closed once that is done,
+// or after closeGracePeriod if the client is not reading.
 func (c *Connection) Close() {
 	c.closeOnce.Do(func() {
 		close(c.closed)
 		close(c.send)
-		c.conn.Close()
+		connections.Delete(c)
+		time.AfterFunc(closeGracePeriod, func() {
+			c.conn.Close()
+		})
 	})
 }
 
@@ -81,7 +99,9 @@ func (c *Connection) IsClosed() bool {
 func (c *Connection) SendBinary(data []byte) (err error) {
 	select {
 	case <-c.closed:
-		log.Printf("connection closed, returning error")
+		if closedSendSampler.Allow() {
+			slog.Debug("connection closed, returning error")
+		}
 		return ErrorConnectionClosed
 	default:
 		select {
@@ -90,6 +110,7 @@ func (c *Connection) SendBinary(data []byte) (err error) {
 		default:
 			// Buffer probably full
 			// c.Close()
+			bufferFullTotal.Inc()
 			return ErrorBufferFull
 		}
 	}
@@ -98,7 +119,7 @@ func (c *Connection) SendBinary(data []byte) (err error) {
 func (c *Connection) readPump() {
 	defer c.Close()
 	var tzero time.Time
-	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadLimit(c.maxMessageSize)
 	c.conn.SetReadDeadline(tzero)
 	c.conn.SetPongHandler(func(string) error {
 		c.conn.SetReadDeadline(tzero)
@@ -109,7 +130,7 @@ func (c *Connection) readPump() {
 		_, message, err := c.conn.ReadMessage()
 		if err != nil {
 			if ws.IsUnexpectedCloseError(err, ws.CloseGoingAway, ws.CloseAbnormalClosure) {
-				log.Printf("error during websocket pump: %v", err)
+				slog.Warn("error during websocket pump", "remoteAddr", c.conn.RemoteAddr(), "err", err)
 			}
 			c.Close()
 			return
@@ -134,7 +155,7 @@ func (c *Connection) writePump() {
 		case message, ok := <-c.send:
 			c.conn.SetWriteDeadline(tzero)
 			if !ok {
-				c.conn.WriteMessage(ws.CloseMessage, []byte{})
+				c.conn.WriteMessage(ws.CloseMessage, ws.FormatCloseMessage(ws.CloseNormalClosure, ""))
 				return
 			}
 
@@ -150,13 +171,10 @@ func (c *Connection) writePump() {
 			}
 
 			if err := w.Close(); err != nil {
-				log.Printf("error while closing writepump %v", err)
+				slog.Warn("error while closing writepump", "remoteAddr", c.conn.RemoteAddr(), "err", err)
 				return
 			}
 
-		case <-c.closed:
-			return
-
 		// case <-ticker.C:
 		// 	log.Printf("ticker clock")
 		// 	c.conn.SetWriteDeadline(tzero)
@@ -0,0 +1,43 @@
+package websockets
+
+import (
+	"sync"
+
+	"galaxy.io/server/metrics"
+)
+
+// connections holds every open connection, so their send queues can be
+// inspected.
+var connections sync.Map
+
+var bufferFullTotal = metrics.NewCounter(
+	"galaxy_websocket_buffer_full_total",
+	"Messages dropped because the send queue of a connection was full.",
+)
+
+func init() {
+	metrics.NewGaugeFunc("galaxy_websocket_connections", "Open websocket connections.", func() float64 {
+		count := 0
+		connections.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+		return float64(count)
+	})
+	metrics.NewGaugeFunc("galaxy_websocket_send_queue_depth", "Messages waiting in the send queues of every connection.", func() float64 {
+		depth := 0
+		connections.Range(func(key, _ any) bool {
+			depth += len(key.(*Connection).send)
+			return true
+		})
+		return float64(depth)
+	})
+	metrics.NewGaugeFunc("galaxy_websocket_send_queue_max_depth", "Messages waiting in the fullest send queue.", func() float64 {
+		maxDepth := 0
+		connections.Range(func(key, _ any) bool {
+			maxDepth = max(maxDepth, len(key.(*Connection).send))
+			return true
+		})
+		return float64(maxDepth)
+	})
+}